@@ -21,7 +21,7 @@ func RandomGraph(numVertices, numEdges int) *graph.Graph {
 
 		// Проверка на дубликаты и наличие самоциклов
 		if u != v && !graph.HasEdge(g, u, v) {
-			g.AddEdge(u, v)
+			g.AddEdge(u, v, 1)
 			edgesAdded++
 		}
 	}
@@ -35,7 +35,10 @@ func main() {
 
 	g := RandomGraph(numVertices, numEdges)
 
-	fmt.Println("Структура графа:", g.adj)
+	fmt.Println("Структура графа:")
+	for _, v := range g.Vertices() {
+		fmt.Println(v, g.Neighbors(v))
+	}
 
 	fmt.Println("Есть ли связь между 0 и 1?", graph.HasEdge(g, 0, 1))
 	fmt.Println("Есть ли связь между 0 и 3?", graph.HasEdge(g, 0, 3))
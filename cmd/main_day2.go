@@ -23,8 +23,9 @@ func main() {
 
 	ds.Union(2, 3)
 
-	fmt.Printf("родитель: %v\n", ds.parent)
-	fmt.Printf("ранг: %v\n", ds.rank)
+	for i := 0; i < 6; i++ {
+		fmt.Printf("После объединения 2 и 3: Найдено(%d): %d\n", i, ds.Find(i))
+	}
 
 	g := graph.NewGraph()
 	g.AddEdge(0, 1, 4)
@@ -35,22 +36,28 @@ func main() {
 
 	edges := g.GetAllEdges()
 
-	mst, totalWeight := graph.MST(len(g.adj), edges)
+	mst, totalWeight := graph.MST(g.NumVertices(), edges)
 	fmt.Println("MST:")
 	for _, edge := range mst {
-		fmt.Printf("%d -- %d (вес: %d)\n", edge.u, edge.v, edge.w)
+		fmt.Printf("%d -- %d (вес: %d)\n", edge.From, edge.To, edge.Weight)
 	}
 	fmt.Printf("Общий вес MST: %d\n", totalWeight)
 
 	fmt.Println("\nДейкстра:")
 	start := 0
-	dist, _ := graph.Dijkstra(g, start)
-	fmt.Println("Расстояния от начальной вершины:", dist)
+	tree := graph.Dijkstra(g, start)
+	fmt.Println("Расстояния от начальной вершины:")
+	for _, v := range g.Vertices() {
+		fmt.Printf("%d: %d, путь %v\n", v, tree.DistTo(v), tree.PathTo(v))
+	}
 
-	bfDist, hasNegativeCycle := graph.BellmanFord(g, start)
-	if hasNegativeCycle {
-		fmt.Println("Обнаружен отрицательный цикл")
+	bfTree, negativeCycle := graph.BellmanFord(g, start)
+	if negativeCycle != nil {
+		fmt.Println("Обнаружен отрицательный цикл:", negativeCycle)
 	} else {
-		fmt.Println("Расстояния Беллмана-Форда от начальной вершины:", bfDist)
+		fmt.Println("Расстояния Беллмана-Форда от начальной вершины:")
+		for _, v := range g.Vertices() {
+			fmt.Printf("%d: %d\n", v, bfTree.DistTo(v))
+		}
 	}
 }
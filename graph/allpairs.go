@@ -0,0 +1,156 @@
+package graph
+
+// DistanceMatrix - кратчайшие расстояния между всеми парами вершин
+type DistanceMatrix struct {
+	ids    []int       // ID вершин по возрастанию
+	index  map[int]int // ID -> номер строки
+	dist   [][]int
+	parent [][]int // parent[i][j] - номер предпоследней вершины пути i -> j или -1
+}
+
+func newDistanceMatrix(ids []int) *DistanceMatrix {
+	m := &DistanceMatrix{
+		ids:    ids,
+		index:  make(map[int]int, len(ids)),
+		dist:   make([][]int, len(ids)),
+		parent: make([][]int, len(ids)),
+	}
+	for i, id := range ids {
+		m.index[id] = i
+		m.dist[i] = make([]int, len(ids))
+		m.parent[i] = make([]int, len(ids))
+		for j := range ids {
+			m.dist[i][j] = Infinity
+			m.parent[i][j] = -1
+		}
+		m.dist[i][i] = 0
+	}
+	return m
+}
+
+// Vertices - вершины матрицы по возрастанию
+func (m *DistanceMatrix) Vertices() []int {
+	ids := make([]int, len(m.ids))
+	copy(ids, m.ids)
+	return ids
+}
+
+// Dist - расстояние от u до v или Infinity
+func (m *DistanceMatrix) Dist(u, v int) int {
+	i, ok1 := m.index[u]
+	j, ok2 := m.index[v]
+	if !ok1 || !ok2 {
+		return Infinity
+	}
+	return m.dist[i][j]
+}
+
+// HasPath - есть ли путь от u до v
+func (m *DistanceMatrix) HasPath(u, v int) bool {
+	return m.Dist(u, v) != Infinity
+}
+
+// Path - вершины кратчайшего пути от u до v или nil
+func (m *DistanceMatrix) Path(u, v int) []int {
+	if !m.HasPath(u, v) {
+		return nil
+	}
+	i, j := m.index[u], m.index[v]
+	path := []int{v}
+	for j != i {
+		j = m.parent[i][j]
+		path = append(path, m.ids[j])
+	}
+	for a, b := 0, len(path)-1; a < b; a, b = a+1, b-1 {
+		path[a], path[b] = path[b], path[a]
+	}
+	return path
+}
+
+// FloydWarshall - все пары кратчайших путей за O(n^3), для плотных графов.
+// При отрицательном цикле возвращает nil и вершины цикла.
+func FloydWarshall(g ReadOnlyGraph) (*DistanceMatrix, []int) {
+	m := newDistanceMatrix(g.Vertices())
+	n := len(m.ids)
+	for i, u := range m.ids {
+		for _, neighbor := range g.Neighbors(u) {
+			j := m.index[neighbor.To]
+			if neighbor.Weight < m.dist[i][j] {
+				m.dist[i][j] = neighbor.Weight
+				m.parent[i][j] = i
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if m.dist[i][k] == Infinity {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if m.dist[k][j] == Infinity {
+					continue
+				}
+				if d := m.dist[i][k] + m.dist[k][j]; d < m.dist[i][j] {
+					m.dist[i][j] = d
+					m.parent[i][j] = m.parent[k][j]
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if m.dist[i][i] < 0 {
+			_, cycle := SPFA(g, m.ids[i])
+			return nil, cycle
+		}
+	}
+	return m, nil
+}
+
+// Johnson - все пары кратчайших путей для разреженных графов:
+// веса перевзвешиваются потенциалами из BellmanFord, затем из каждой
+// вершины запускается Dijkstra. При отрицательном цикле возвращает nil
+// и вершины цикла.
+func Johnson(g ReadOnlyGraph) (*DistanceMatrix, []int) {
+	ids := g.Vertices()
+	m := newDistanceMatrix(ids)
+	if len(ids) == 0 {
+		return m, nil
+	}
+
+	// фиктивная вершина q с рёбрами веса 0 во все вершины
+	q := ids[0] - 1
+	aux := NewDirectedGraph()
+	for _, u := range ids {
+		aux.AddEdge(q, u, 0)
+		for _, neighbor := range g.Neighbors(u) {
+			aux.AddEdge(u, neighbor.To, neighbor.Weight)
+		}
+	}
+	potentials, cycle := BellmanFord(aux, q)
+	if cycle != nil {
+		return nil, cycle
+	}
+	h := func(v int) int { return potentials.DistTo(v) }
+
+	reweighted := NewDirectedGraph()
+	for _, u := range ids {
+		reweighted.AddVertex(u)
+		for _, neighbor := range g.Neighbors(u) {
+			reweighted.AddEdge(u, neighbor.To, neighbor.Weight+h(u)-h(neighbor.To))
+		}
+	}
+
+	for i, u := range ids {
+		tree := Dijkstra(reweighted, u)
+		for v, d := range tree.dist {
+			j := m.index[v]
+			m.dist[i][j] = d - h(u) + h(v)
+			if p, ok := tree.parent[v]; ok {
+				m.parent[i][j] = m.index[p]
+			}
+		}
+	}
+	return m, nil
+}
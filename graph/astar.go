@@ -0,0 +1,138 @@
+package graph
+
+import "container/heap"
+
+// Heuristic - нижняя оценка расстояния от вершины v до цели
+type Heuristic func(v int) int
+
+// AStar - поиск кратчайшего пути от source до target с эвристикой h.
+// Эвристика не должна переоценивать расстояние, веса - неотрицательные.
+// Возвращает путь, его длину и false, если target недостижима.
+func AStar(g ReadOnlyGraph, source, target int, h Heuristic) ([]int, int, bool) {
+	if h == nil {
+		h = func(int) int { return 0 }
+	}
+	tree := newShortestPathTree(source)
+
+	pq := &PriorityQueue{}
+	heap.Push(pq, &Item{vertex: source, dist: h(source)})
+
+	for pq.Len() > 0 {
+		u := heap.Pop(pq).(*Item)
+		du := tree.dist[u.vertex]
+		if u.dist > du+h(u.vertex) {
+			continue
+		}
+		if u.vertex == target {
+			return tree.PathTo(target), du, true
+		}
+		for _, neighbor := range g.Neighbors(u.vertex) {
+			d := du + neighbor.Weight
+			if old, ok := tree.dist[neighbor.To]; !ok || d < old {
+				tree.dist[neighbor.To] = d
+				tree.parent[neighbor.To] = u.vertex
+				heap.Push(pq, &Item{vertex: neighbor.To, dist: d + h(neighbor.To)})
+			}
+		}
+	}
+	return nil, Infinity, false
+}
+
+// reversedGraph - тот же граф с развёрнутыми рёбрами
+type reversedGraph struct {
+	ReadOnlyGraph
+}
+
+func (r reversedGraph) Neighbors(u int) []Neighbor {
+	return r.ReadOnlyGraph.InNeighbors(u)
+}
+
+func (r reversedGraph) InNeighbors(u int) []Neighbor {
+	return r.ReadOnlyGraph.Neighbors(u)
+}
+
+// Landmarks - предподсчёт для ALT (A*, landmarks, triangle inequality):
+// расстояния от ориентиров и до них дают нижние оценки для A*
+type Landmarks struct {
+	vertices []int
+	from     []*ShortestPathTree // расстояния от ориентира до вершин
+	to       []*ShortestPathTree // расстояния от вершин до ориентира
+}
+
+// NewLandmarks - выбирает k ориентиров жадно (каждый следующий - самая
+// далёкая от уже выбранных вершина) и считает до них расстояния Dijkstra
+func NewLandmarks(g ReadOnlyGraph, k int) *Landmarks {
+	lm := &Landmarks{}
+	vertices := g.Vertices()
+	if len(vertices) == 0 || k <= 0 {
+		return lm
+	}
+
+	// ближайшее расстояние от вершины до выбранных ориентиров
+	nearest := make(map[int]int, len(vertices))
+	for _, v := range vertices {
+		nearest[v] = Infinity
+	}
+
+	next := vertices[0]
+	for len(lm.vertices) < k && len(lm.vertices) < len(vertices) {
+		lm.add(g, next)
+
+		best, bestDist := next, -1
+		for _, v := range vertices {
+			if d := lm.from[len(lm.from)-1].DistTo(v); d < nearest[v] {
+				nearest[v] = d
+			}
+			if nearest[v] > bestDist {
+				best, bestDist = v, nearest[v]
+			}
+		}
+		if bestDist == 0 {
+			break
+		}
+		next = best
+	}
+	return lm
+}
+
+func (lm *Landmarks) add(g ReadOnlyGraph, v int) {
+	lm.vertices = append(lm.vertices, v)
+	from := Dijkstra(g, v)
+	to := from
+	if g.IsDirected() {
+		to = Dijkstra(reversedGraph{g}, v)
+	}
+	lm.from = append(lm.from, from)
+	lm.to = append(lm.to, to)
+}
+
+// Vertices - выбранные ориентиры
+func (lm *Landmarks) Vertices() []int {
+	vertices := make([]int, len(lm.vertices))
+	copy(vertices, lm.vertices)
+	return vertices
+}
+
+// Heuristic - эвристика для цели target по неравенству треугольника:
+// d(v,t) >= d(L,t) - d(L,v) и d(v,t) >= d(v,L) - d(t,L)
+func (lm *Landmarks) Heuristic(target int) Heuristic {
+	return func(v int) int {
+		best := 0
+		for i := range lm.vertices {
+			lt, lv := lm.from[i].DistTo(target), lm.from[i].DistTo(v)
+			if lt != Infinity && lv != Infinity && lt-lv > best {
+				best = lt - lv
+			}
+			vl, tl := lm.to[i].DistTo(v), lm.to[i].DistTo(target)
+			if vl != Infinity && tl != Infinity && vl-tl > best {
+				best = vl - tl
+			}
+		}
+		return best
+	}
+}
+
+// ShortestPath - запрос A* с эвристикой ориентиров
+func (lm *Landmarks) ShortestPath(g ReadOnlyGraph, source, target int) ([]int, int, bool) {
+	return AStar(g, source, target, lm.Heuristic(target))
+}
@@ -1,7 +1,7 @@
 package graph
 
 // BFS
-func BFS(g *Graph, start int) []int {
+func BFS(g ReadOnlyGraph, start int) []int {
 	visited := make(map[int]bool)
 	order := []int{}
 	queue := Queue{}
@@ -13,10 +13,10 @@ func BFS(g *Graph, start int) []int {
 		u, _ := queue.Dequeue()
 		order = append(order, u)
 
-		for _, v := range g.adj[u] {
-			if !visited[v] {
-				visited[v] = true
-				queue.Enqueue(v)
+		for _, neighbor := range g.Neighbors(u) {
+			if !visited[neighbor.To] {
+				visited[neighbor.To] = true
+				queue.Enqueue(neighbor.To)
 			}
 		}
 	}
@@ -24,34 +24,34 @@ func BFS(g *Graph, start int) []int {
 }
 
 // DFS
-func (g *Graph) dfsUtil(v int, visited map[int]bool, order *[]int) {
+func dfsUtil(g ReadOnlyGraph, v int, visited map[int]bool, order *[]int) {
 	visited[v] = true
 	*order = append(*order, v)
 
-	for _, u := range g.adj[v] {
-		if !visited[u] {
-			g.dfsUtil(u, visited, order)
+	for _, neighbor := range g.Neighbors(v) {
+		if !visited[neighbor.To] {
+			dfsUtil(g, neighbor.To, visited, order)
 		}
 	}
 }
 
-func DFS(g *Graph, start int) []int {
+func DFS(g ReadOnlyGraph, start int) []int {
 	visited := make(map[int]bool)
 	order := []int{}
-	g.dfsUtil(start, visited, &order)
+	dfsUtil(g, start, visited, &order)
 	return order
 }
 
-func ConnectedComponents(g *Graph) (count int, comp map[int]int) {
+func ConnectedComponents(g ReadOnlyGraph) (count int, comp map[int]int) {
 	visited := make(map[int]bool)
 	comp = make(map[int]int)
 	count = 0
 
-	for v := range g.adj {
+	for _, v := range g.Vertices() {
 		if !visited[v] {
 			count++
 			order := []int{}
-			g.dfsUtil(v, visited, &order)
+			dfsUtil(g, v, visited, &order)
 			for _, u := range order {
 				comp[u] = count
 			}
@@ -59,3 +59,80 @@ func ConnectedComponents(g *Graph) (count int, comp map[int]int) {
 	}
 	return count, comp
 }
+
+// ShortestHopPath - кратчайший по числу рёбер путь от u до v двусторонним BFS.
+// Поиск прекращается, как только фронты встретились или превышен лимит
+// maxHops (maxHops <= 0 - без ограничения). Возвращает путь, число рёбер
+// и false, если путь не найден.
+func ShortestHopPath(g ReadOnlyGraph, u, v, maxHops int) ([]int, int, bool) {
+	if !g.HasVertex(u) || !g.HasVertex(v) {
+		return nil, -1, false
+	}
+	if u == v {
+		return []int{u}, 0, true
+	}
+
+	// расстояния и родители для прямого и обратного поиска
+	distF := map[int]int{u: 0}
+	distB := map[int]int{v: 0}
+	parentF := map[int]int{}
+	parentB := map[int]int{}
+	frontF := []int{u}
+	frontB := []int{v}
+	depthF, depthB := 0, 0
+
+	for len(frontF) > 0 && len(frontB) > 0 {
+		if maxHops > 0 && depthF+depthB >= maxHops {
+			break
+		}
+
+		// расширяем меньший фронт на один уровень
+		forward := len(frontF) <= len(frontB)
+		front, dist, parent, other := frontF, distF, parentF, distB
+		neighbors := g.Neighbors
+		if !forward {
+			front, dist, parent, other = frontB, distB, parentB, distF
+			neighbors = g.InNeighbors
+		}
+
+		best, meet := -1, 0
+		var next []int
+		for _, x := range front {
+			for _, neighbor := range neighbors(x) {
+				w := neighbor.To
+				if _, seen := dist[w]; !seen {
+					dist[w] = dist[x] + 1
+					parent[w] = x
+					next = append(next, w)
+				}
+				if d, ok := other[w]; ok && dist[w] == dist[x]+1 {
+					if total := dist[w] + d; best < 0 || total < best {
+						best, meet = total, w
+					}
+				}
+			}
+		}
+
+		if forward {
+			frontF, depthF = next, depthF+1
+		} else {
+			frontB, depthB = next, depthB+1
+		}
+		if best >= 0 {
+			return joinHopPath(meet, parentF, parentB), best, true
+		}
+	}
+	return nil, -1, false
+}
+
+// joinHopPath - склеивает путь от начала до meet и от meet до конца
+func joinHopPath(meet int, parentF, parentB map[int]int) []int {
+	var path []int
+	for x, ok := meet, true; ok; x, ok = parentF[x] {
+		path = append([]int{x}, path...)
+	}
+	for x, ok := parentB[meet]; ok; x, ok = parentB[x] {
+		path = append(path, x)
+	}
+	return path
+}
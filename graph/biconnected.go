@@ -0,0 +1,131 @@
+package graph
+
+import "sort"
+
+// Точки сочленения, мосты и компоненты двусвязности неориентированного
+// графа. Обход в глубину итеративный, поэтому глубина графа не ограничена
+// размером стека.
+
+// biconnectedResult - всё, что находит один обход
+type biconnectedResult struct {
+	points     []int
+	bridges    []Edge
+	components [][]Edge
+}
+
+func biconnected(g ReadOnlyGraph) biconnectedResult {
+	var res biconnectedResult
+	disc := make(map[int]int)
+	low := make(map[int]int)
+	isPoint := make(map[int]bool)
+	var edgeStack []Edge
+
+	type frame struct {
+		v, parent int
+		weight    int // вес ребра из родителя
+		next      int
+		children  int
+	}
+
+	for _, root := range g.Vertices() {
+		if _, seen := disc[root]; seen {
+			continue
+		}
+		disc[root], low[root] = len(disc), len(disc)
+		frames := []frame{{v: root, parent: root}}
+
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			v := f.v
+			neighbors := g.Neighbors(v)
+			if f.next < len(neighbors) {
+				neighbor := neighbors[f.next]
+				w := neighbor.To
+				f.next++
+				if w == v || w == f.parent {
+					continue
+				}
+				if _, seen := disc[w]; !seen {
+					f.children++
+					disc[w], low[w] = len(disc), len(disc)
+					edgeStack = append(edgeStack, Edge{From: v, To: w, Weight: neighbor.Weight})
+					frames = append(frames, frame{v: w, parent: v, weight: neighbor.Weight})
+				} else if disc[w] < disc[v] {
+					// обратное ребро к предку
+					edgeStack = append(edgeStack, Edge{From: v, To: w, Weight: neighbor.Weight})
+					if disc[w] < low[v] {
+						low[v] = disc[w]
+					}
+				}
+				continue
+			}
+
+			// возврат из v в родителя p
+			children, weight := f.children, f.weight
+			frames = frames[:len(frames)-1]
+			if len(frames) == 0 {
+				if children > 1 {
+					isPoint[v] = true
+				}
+				continue
+			}
+			p := frames[len(frames)-1].v
+			if low[v] < low[p] {
+				low[p] = low[v]
+			}
+			if low[v] >= disc[p] {
+				if p != root {
+					isPoint[p] = true
+				}
+				var component []Edge
+				for {
+					edge := edgeStack[len(edgeStack)-1]
+					edgeStack = edgeStack[:len(edgeStack)-1]
+					component = append(component, edge)
+					if edge.From == p && edge.To == v {
+						break
+					}
+				}
+				res.components = append(res.components, component)
+			}
+			if low[v] > disc[p] {
+				res.bridges = append(res.bridges, orderedEdge(p, v, weight))
+			}
+		}
+	}
+
+	for v := range isPoint {
+		res.points = append(res.points, v)
+	}
+	sort.Ints(res.points)
+	sort.Slice(res.bridges, func(i, j int) bool {
+		if res.bridges[i].From != res.bridges[j].From {
+			return res.bridges[i].From < res.bridges[j].From
+		}
+		return res.bridges[i].To < res.bridges[j].To
+	})
+	return res
+}
+
+func orderedEdge(u, v, w int) Edge {
+	if u > v {
+		u, v = v, u
+	}
+	return Edge{From: u, To: v, Weight: w}
+}
+
+// ArticulationPoints - пользователи, удаление которых увеличивает
+// число компонент связности
+func ArticulationPoints(g ReadOnlyGraph) []int {
+	return biconnected(g).points
+}
+
+// Bridges - дружбы, удаление которых разбивает компоненту (From < To)
+func Bridges(g ReadOnlyGraph) []Edge {
+	return biconnected(g).bridges
+}
+
+// BiconnectedComponents - рёбра каждой компоненты двусвязности
+func BiconnectedComponents(g ReadOnlyGraph) [][]Edge {
+	return biconnected(g).components
+}
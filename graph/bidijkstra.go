@@ -0,0 +1,87 @@
+package graph
+
+import "container/heap"
+
+// dijkstraSide - состояние одного направления двустороннего поиска
+type dijkstraSide struct {
+	g    ReadOnlyGraph
+	tree *ShortestPathTree
+	pq   *PriorityQueue
+	done map[int]bool
+}
+
+func newDijkstraSide(g ReadOnlyGraph, source int) *dijkstraSide {
+	side := &dijkstraSide{
+		g:    g,
+		tree: newShortestPathTree(source),
+		pq:   &PriorityQueue{},
+		done: make(map[int]bool),
+	}
+	heap.Push(side.pq, &Item{vertex: source, dist: 0})
+	return side
+}
+
+// top - минимальный ключ в очереди без устаревших записей
+func (s *dijkstraSide) top() int {
+	for s.pq.Len() > 0 {
+		item := (*s.pq)[0]
+		if !s.done[item.vertex] && item.dist == s.tree.dist[item.vertex] {
+			return item.dist
+		}
+		heap.Pop(s.pq)
+	}
+	return Infinity
+}
+
+// BidirectionalDijkstra - кратчайший путь от s до t поиском с двух сторон.
+// Останавливается, когда сумма минимальных ключей двух очередей не меньше
+// лучшего найденного пути. Веса должны быть неотрицательными.
+func BidirectionalDijkstra(g ReadOnlyGraph, s, t int) ([]int, int, bool) {
+	if !g.HasVertex(s) || !g.HasVertex(t) {
+		return nil, Infinity, false
+	}
+	if s == t {
+		return []int{s}, 0, true
+	}
+
+	forward := newDijkstraSide(g, s)
+	backward := newDijkstraSide(reversedGraph{g}, t)
+	best, meet := Infinity, 0
+
+	for {
+		topF, topB := forward.top(), backward.top()
+		if topF == Infinity || topB == Infinity || topF+topB >= best {
+			break
+		}
+
+		side, other := forward, backward
+		if topB < topF {
+			side, other = backward, forward
+		}
+		u := heap.Pop(side.pq).(*Item).vertex
+		side.done[u] = true
+		du := side.tree.dist[u]
+
+		for _, neighbor := range side.g.Neighbors(u) {
+			v, d := neighbor.To, du+neighbor.Weight
+			if old, ok := side.tree.dist[v]; !ok || d < old {
+				side.tree.dist[v] = d
+				side.tree.parent[v] = u
+				heap.Push(side.pq, &Item{vertex: v, dist: d})
+			}
+			if dv, ok := other.tree.dist[v]; ok && side.tree.dist[v]+dv < best {
+				best, meet = side.tree.dist[v]+dv, v
+			}
+		}
+	}
+
+	if best == Infinity {
+		return nil, Infinity, false
+	}
+	path := forward.tree.PathTo(meet)
+	back := backward.tree.PathTo(meet)
+	for i := len(back) - 2; i >= 0; i-- {
+		path = append(path, back[i])
+	}
+	return path, best, true
+}
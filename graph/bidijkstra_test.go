@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+const (
+	benchVertices = 100000
+	benchQueries  = 64
+)
+
+// benchGraph - кольцо со случайными хордами и пары s/t для запросов;
+// генерация детерминирована, чтобы обе версии шли на одних данных
+func benchGraph() (*Graph, [][2]int) {
+	rng := rand.New(rand.NewSource(1))
+	g := NewGraph()
+	for i := 0; i < benchVertices; i++ {
+		g.AddEdge(i, (i+1)%benchVertices, 1+rng.Intn(5))
+		g.AddEdge(i, rng.Intn(benchVertices), 5+rng.Intn(50))
+	}
+	pairs := make([][2]int, benchQueries)
+	for i := range pairs {
+		s := rng.Intn(benchVertices)
+		pairs[i] = [2]int{s, (s + 1 + rng.Intn(200)) % benchVertices}
+	}
+	return g, pairs
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	g, pairs := benchGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := pairs[i%len(pairs)]
+		Dijkstra(g, p[0]).PathTo(p[1])
+	}
+}
+
+func BenchmarkBidirectionalDijkstra(b *testing.B) {
+	g, pairs := benchGraph()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := pairs[i%len(pairs)]
+		BidirectionalDijkstra(g, p[0], p[1])
+	}
+}
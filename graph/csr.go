@@ -0,0 +1,171 @@
+package graph
+
+import "sort"
+
+// CSRGraph - неизменяемый граф в формате compressed sparse row.
+// Соседи каждой вершины лежат в одном массиве и отсортированы по To,
+// поэтому проверка ребра занимает O(log d); строка вершины по её ID
+// находится за O(1).
+type CSRGraph struct {
+	ids       []int       // ID вершин по возрастанию
+	base      int         // наименьший ID
+	dense     []int       // dense[u-base] - номер строки u плюс 1, 0 - вершины нет
+	sparse    map[int]int // номер строки по ID, если ID слишком разрежены для dense
+	offsets   []int       // соседи вершины ids[i] - edges[offsets[i]:offsets[i+1]]
+	edges     []Neighbor  // исходящие рёбра
+	inOffsets []int       // то же для входящих рёбер (только ориентированный граф)
+	inEdges   []Neighbor
+	directed  bool
+}
+
+// NewCSR - строит CSR-представление графа g
+func NewCSR(g *Graph) *CSRGraph {
+	c := &CSRGraph{ids: g.Vertices(), directed: g.directed}
+	c.buildIndex()
+	c.offsets, c.edges = packAdjacency(c.ids, g.adj)
+	if g.directed {
+		c.inOffsets, c.inEdges = packAdjacency(c.ids, g.in)
+	}
+	return c
+}
+
+func packAdjacency(ids []int, adj map[int][]Neighbor) ([]int, []Neighbor) {
+	offsets := make([]int, len(ids)+1)
+	for i, u := range ids {
+		offsets[i+1] = offsets[i] + len(adj[u])
+	}
+	edges := make([]Neighbor, offsets[len(ids)])
+	for i, u := range ids {
+		row := edges[offsets[i]:offsets[i+1]]
+		copy(row, adj[u])
+		sort.Slice(row, func(a, b int) bool { return row[a].To < row[b].To })
+	}
+	return offsets, edges
+}
+
+// maxDenseSpread - во сколько раз диапазон ID может превышать число
+// вершин, чтобы индекс ещё хранился плотным массивом
+const maxDenseSpread = 4
+
+// buildIndex - строит индекс ID -> строка: плотный массив, если ID
+// лежат достаточно кучно, иначе map
+func (c *CSRGraph) buildIndex() {
+	n := len(c.ids)
+	if n == 0 {
+		return
+	}
+	c.base = c.ids[0]
+	// разность в uint не переполняется даже для крайних значений int
+	span := uint(c.ids[n-1]) - uint(c.base)
+	if span < uint(maxDenseSpread*n) {
+		c.dense = make([]int, span+1)
+		for i, u := range c.ids {
+			c.dense[uint(u)-uint(c.base)] = i + 1
+		}
+		return
+	}
+	c.sparse = make(map[int]int, n)
+	for i, u := range c.ids {
+		c.sparse[u] = i
+	}
+}
+
+// row - индекс вершины u в массиве ids
+func (c *CSRGraph) row(u int) (int, bool) {
+	if c.sparse != nil {
+		i, ok := c.sparse[u]
+		return i, ok
+	}
+	k := uint(u) - uint(c.base)
+	if k >= uint(len(c.dense)) {
+		return 0, false
+	}
+	i := c.dense[k] - 1
+	return i, i >= 0
+}
+
+func (c *CSRGraph) IsDirected() bool {
+	return c.directed
+}
+
+func (c *CSRGraph) NumVertices() int {
+	return len(c.ids)
+}
+
+// NumEdges - количество рёбер (неориентированное ребро считается один раз)
+func (c *CSRGraph) NumEdges() int {
+	if c.directed {
+		return len(c.edges)
+	}
+	loops := 0
+	for i, u := range c.ids {
+		if searchNeighbor(c.edges[c.offsets[i]:c.offsets[i+1]], u) >= 0 {
+			loops++
+		}
+	}
+	return (len(c.edges) + loops) / 2
+}
+
+// Vertices - возвращает копию списка вершин по возрастанию
+func (c *CSRGraph) Vertices() []int {
+	ids := make([]int, len(c.ids))
+	copy(ids, c.ids)
+	return ids
+}
+
+func (c *CSRGraph) HasVertex(u int) bool {
+	_, ok := c.row(u)
+	return ok
+}
+
+// Neighbors - соседи u, отсортированные по To; срез нельзя изменять
+func (c *CSRGraph) Neighbors(u int) []Neighbor {
+	i, ok := c.row(u)
+	if !ok {
+		return nil
+	}
+	return c.edges[c.offsets[i]:c.offsets[i+1]:c.offsets[i+1]]
+}
+
+// InNeighbors - вершины с ребром в u; срез нельзя изменять
+func (c *CSRGraph) InNeighbors(u int) []Neighbor {
+	if !c.directed {
+		return c.Neighbors(u)
+	}
+	i, ok := c.row(u)
+	if !ok {
+		return nil
+	}
+	return c.inEdges[c.inOffsets[i]:c.inOffsets[i+1]:c.inOffsets[i+1]]
+}
+
+func (c *CSRGraph) OutDegree(u int) int {
+	return len(c.Neighbors(u))
+}
+
+func (c *CSRGraph) InDegree(u int) int {
+	return len(c.InNeighbors(u))
+}
+
+// HasEdge - проверяет ребро u-v бинарным поиском
+func (c *CSRGraph) HasEdge(u, v int) bool {
+	return searchNeighbor(c.Neighbors(u), v) >= 0
+}
+
+// Weight - возвращает вес ребра u-v, если оно есть
+func (c *CSRGraph) Weight(u, v int) (int, bool) {
+	neighbors := c.Neighbors(u)
+	j := searchNeighbor(neighbors, v)
+	if j < 0 {
+		return 0, false
+	}
+	return neighbors[j].Weight, true
+}
+
+func searchNeighbor(neighbors []Neighbor, v int) int {
+	j := sort.Search(len(neighbors), func(k int) bool { return neighbors[k].To >= v })
+	if j < len(neighbors) && neighbors[j].To == v {
+		return j
+	}
+	return -1
+}
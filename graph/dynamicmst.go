@@ -0,0 +1,161 @@
+package graph
+
+import "sort"
+
+// DynamicMST - минимальный остовный лес, который поддерживается при
+// добавлении, удалении и изменении веса рёбер без полного пересчёта.
+// Работает с неориентированным графом.
+type DynamicMST struct {
+	g     *Graph // все рёбра
+	tree  *Graph // рёбра текущего остовного леса
+	total int
+}
+
+// NewDynamicMST - строит начальный лес алгоритмом Краскала
+func NewDynamicMST(g *Graph) *DynamicMST {
+	d := &DynamicMST{g: NewGraph(), tree: NewGraph()}
+	for _, v := range g.Vertices() {
+		d.g.AddVertex(v)
+		d.tree.AddVertex(v)
+	}
+	for _, edge := range g.GetAllEdges() {
+		d.g.AddEdge(edge.From, edge.To, edge.Weight)
+	}
+	for _, edge := range Kruskal(d.g, MinimumSpanning).Edges() {
+		d.link(edge.From, edge.To, edge.Weight)
+	}
+	return d
+}
+
+// TotalWeight - текущий вес остовного леса
+func (d *DynamicMST) TotalWeight() int {
+	return d.total
+}
+
+// Edges - рёбра текущего остовного леса
+func (d *DynamicMST) Edges() []Edge {
+	return d.tree.GetAllEdges()
+}
+
+// InTree - входит ли ребро u-v в остовный лес
+func (d *DynamicMST) InTree(u, v int) bool {
+	return HasEdge(d.tree, u, v)
+}
+
+// AddEdge - добавляет ребро. Если u и v уже связаны в лесу, ребро
+// заменяет самое тяжёлое ребро на пути между ними (свойство цикла).
+func (d *DynamicMST) AddEdge(u, v, weight int) {
+	if HasEdge(d.g, u, v) {
+		d.UpdateWeight(u, v, weight)
+		return
+	}
+	d.g.AddEdge(u, v, weight)
+	d.tree.AddVertex(u)
+	d.tree.AddVertex(v)
+	d.insert(u, v, weight)
+}
+
+// RemoveEdge - удаляет ребро; если оно было в лесу, ищет замену
+// среди рёбер, соединяющих две получившиеся части
+func (d *DynamicMST) RemoveEdge(u, v int) bool {
+	if !d.g.RemoveEdge(u, v) {
+		return false
+	}
+	if d.InTree(u, v) {
+		d.cut(u, v)
+		d.reconnect(u)
+	}
+	return true
+}
+
+// UpdateWeight - меняет вес ребра и перестраивает лес при необходимости
+func (d *DynamicMST) UpdateWeight(u, v, weight int) bool {
+	old, ok := d.g.Weight(u, v)
+	if !ok {
+		return false
+	}
+	d.g.UpdateWeight(u, v, weight)
+
+	switch {
+	case d.InTree(u, v) && weight <= old:
+		// подешевевшее ребро дерева остаётся в дереве
+		d.tree.UpdateWeight(u, v, weight)
+		d.total += weight - old
+	case d.InTree(u, v):
+		// подорожавшее ребро дерева может быть заменено
+		d.cut(u, v)
+		d.reconnect(u)
+	case weight < old:
+		// подешевевшее ребро вне дерева может вытеснить ребро цикла
+		d.insert(u, v, weight)
+	}
+	return true
+}
+
+func (d *DynamicMST) link(u, v, weight int) {
+	d.tree.AddEdge(u, v, weight)
+	d.total += weight
+}
+
+func (d *DynamicMST) cut(u, v int) {
+	w, _ := d.tree.Weight(u, v)
+	d.tree.RemoveEdge(u, v)
+	d.total -= w
+}
+
+// insert - применяет свойство цикла для ребра u-v, которого нет в лесу
+func (d *DynamicMST) insert(u, v, weight int) {
+	if u == v {
+		return
+	}
+	path, _, ok := ShortestHopPath(d.tree, u, v, 0)
+	if !ok {
+		d.link(u, v, weight)
+		return
+	}
+
+	heaviest := -1
+	for i := 1; i < len(path); i++ {
+		if heaviest < 0 || d.weightAt(path, i) > d.weightAt(path, heaviest) {
+			heaviest = i
+		}
+	}
+	if d.weightAt(path, heaviest) > weight {
+		d.cut(path[heaviest-1], path[heaviest])
+		d.link(u, v, weight)
+	}
+}
+
+func (d *DynamicMST) weightAt(path []int, i int) int {
+	w, _ := d.tree.Weight(path[i-1], path[i])
+	return w
+}
+
+// reconnect - после разреза дерева ищет самое лёгкое ребро графа
+// между частью, содержащей u, и остальными вершинами; вершины
+// перебираются по возрастанию, чтобы при равных весах выбор не зависел
+// от порядка обхода map
+func (d *DynamicMST) reconnect(u int) {
+	part := BFS(d.tree, u)
+	sort.Ints(part)
+	side := make(map[int]bool)
+	for _, v := range part {
+		side[v] = true
+	}
+
+	var best Edge
+	found := false
+	for _, v := range part {
+		for _, neighbor := range d.g.adj[v] {
+			if side[neighbor.To] {
+				continue
+			}
+			if !found || neighbor.Weight < best.Weight {
+				best, found = Edge{From: v, To: neighbor.To, Weight: neighbor.Weight}, true
+			}
+		}
+	}
+	if found {
+		d.link(best.From, best.To, best.Weight)
+	}
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadEdgeList - читает граф из текстового списка рёбер "u v [w]".
+// Строки, начинающиеся с '#' или '%', считаются комментариями (формат SNAP),
+// вес по умолчанию равен 1. Сжатый gzip поток распознаётся автоматически.
+func ReadEdgeList(r io.Reader, directed bool) (*Graph, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("список рёбер: %v", err)
+		}
+		defer zr.Close()
+		return readEdgeList(zr, directed)
+	}
+	return readEdgeList(br, directed)
+}
+
+func readEdgeList(r io.Reader, directed bool) (*Graph, error) {
+	g := NewGraph()
+	if directed {
+		g = NewDirectedGraph()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' || text[0] == '%' {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 && len(fields) != 3 {
+			return nil, fmt.Errorf("строка %d: ожидалось \"u v [w]\", получено %d полей", line, len(fields))
+		}
+		nums := make([]int, 3)
+		nums[2] = 1
+		for i, field := range fields {
+			x, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("строка %d: некорректное число %q", line, field)
+			}
+			nums[i] = x
+		}
+		g.AddEdge(nums[0], nums[1], nums[2])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("строка %d: %v", line+1, err)
+	}
+	return g, nil
+}
+
+// WriteEdgeList - записывает граф в формате "u v w" с заголовком-комментарием
+func WriteEdgeList(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	edges := g.GetAllEdges()
+
+	kind := "Undirected"
+	if g.directed {
+		kind = "Directed"
+	}
+	fmt.Fprintf(bw, "# %s graph\n", kind)
+	fmt.Fprintf(bw, "# Nodes: %d Edges: %d\n", g.NumVertices(), len(edges))
+	fmt.Fprintf(bw, "# FromNodeId\tToNodeId\tWeight\n")
+	for _, edge := range edges {
+		fmt.Fprintf(bw, "%d\t%d\t%d\n", edge.From, edge.To, edge.Weight)
+	}
+	return bw.Flush()
+}
+
+// LoadEdgeList - читает список рёбер из файла (в том числе .gz)
+func LoadEdgeList(path string, directed bool) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := ReadEdgeList(f, directed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return g, nil
+}
+
+// SaveEdgeList - сохраняет список рёбер в файл, сжимая его, если имя оканчивается на .gz
+func SaveEdgeList(path string, g *Graph) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = f
+	var zw *gzip.Writer
+	if strings.HasSuffix(path, ".gz") {
+		zw = gzip.NewWriter(f)
+		w = zw
+	}
+
+	err = WriteEdgeList(w, g)
+	if zw != nil {
+		if cerr := zw.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// палитра для раскраски компонент связности
+var componentColors = []string{
+	"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3", "#fdb462",
+	"#b3de69", "#fccde5", "#d9d9d9", "#bc80bd", "#ccebc5", "#ffed6f",
+}
+
+const highlightColor = "#e41a1c"
+
+// ExportOptions - настройки экспорта графа
+type ExportOptions struct {
+	Name            string // имя графа, по умолчанию "G"
+	Highlight       []Edge // рёбра для выделения (MST, ShortestPathTree.PathEdges)
+	ColorComponents bool   // раскрасить вершины по компонентам связности
+}
+
+// exportState - общие данные для DOT и GraphML
+type exportState struct {
+	name      string
+	highlight map[[2]int]bool
+	comp      map[int]int
+}
+
+func newExportState(g *Graph, opts ExportOptions) *exportState {
+	st := &exportState{name: opts.Name, highlight: make(map[[2]int]bool)}
+	if st.name == "" {
+		st.name = "G"
+	}
+	for _, edge := range opts.Highlight {
+		st.highlight[edgeKey(g, edge.From, edge.To)] = true
+	}
+	if opts.ColorComponents {
+		_, st.comp = ConnectedComponents(g)
+	}
+	return st
+}
+
+func edgeKey(g *Graph, u, v int) [2]int {
+	if !g.directed && u > v {
+		u, v = v, u
+	}
+	return [2]int{u, v}
+}
+
+func (st *exportState) color(v int) (string, bool) {
+	c, ok := st.comp[v]
+	if !ok {
+		return "", false
+	}
+	return componentColors[(c-1)%len(componentColors)], true
+}
+
+// WriteDOT - записывает граф в формате Graphviz DOT
+func WriteDOT(w io.Writer, g *Graph, opts ExportOptions) error {
+	st := newExportState(g, opts)
+	bw := bufio.NewWriter(w)
+
+	kind, sep := "graph", "--"
+	if g.directed {
+		kind, sep = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s %q {\n", kind, st.name)
+
+	for _, v := range g.Vertices() {
+		if color, ok := st.color(v); ok {
+			fmt.Fprintf(bw, "  %d [style=filled, fillcolor=%q, comp=%d];\n", v, color, st.comp[v])
+		} else {
+			fmt.Fprintf(bw, "  %d;\n", v)
+		}
+	}
+	for _, edge := range g.GetAllEdges() {
+		fmt.Fprintf(bw, "  %d %s %d [label=%d, weight=%d", edge.From, sep, edge.To, edge.Weight, edge.Weight)
+		if st.highlight[edgeKey(g, edge.From, edge.To)] {
+			fmt.Fprintf(bw, ", color=%q, penwidth=3", highlightColor)
+		}
+		fmt.Fprintf(bw, "];\n")
+	}
+
+	fmt.Fprintf(bw, "}\n")
+	return bw.Flush()
+}
+
+// WriteGraphML - записывает граф в формате GraphML
+func WriteGraphML(w io.Writer, g *Graph, opts ExportOptions) error {
+	st := newExportState(g, opts)
+	bw := bufio.NewWriter(w)
+
+	kind := "undirected"
+	if g.directed {
+		kind = "directed"
+	}
+
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(bw, "<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	fmt.Fprintf(bw, "  <key id=\"weight\" for=\"edge\" attr.name=\"weight\" attr.type=\"int\"/>\n")
+	fmt.Fprintf(bw, "  <key id=\"highlight\" for=\"edge\" attr.name=\"highlight\" attr.type=\"boolean\">\n")
+	fmt.Fprintf(bw, "    <default>false</default>\n")
+	fmt.Fprintf(bw, "  </key>\n")
+	if st.comp != nil {
+		fmt.Fprintf(bw, "  <key id=\"component\" for=\"node\" attr.name=\"component\" attr.type=\"int\"/>\n")
+		fmt.Fprintf(bw, "  <key id=\"color\" for=\"node\" attr.name=\"color\" attr.type=\"string\"/>\n")
+	}
+	fmt.Fprintf(bw, "  <graph id=\"%s\" edgedefault=\"%s\">\n", xmlEscape(st.name), kind)
+
+	for _, v := range g.Vertices() {
+		color, ok := st.color(v)
+		if !ok {
+			fmt.Fprintf(bw, "    <node id=\"n%d\"/>\n", v)
+			continue
+		}
+		fmt.Fprintf(bw, "    <node id=\"n%d\">\n", v)
+		fmt.Fprintf(bw, "      <data key=\"component\">%d</data>\n", st.comp[v])
+		fmt.Fprintf(bw, "      <data key=\"color\">%s</data>\n", color)
+		fmt.Fprintf(bw, "    </node>\n")
+	}
+	for i, edge := range g.GetAllEdges() {
+		fmt.Fprintf(bw, "    <edge id=\"e%d\" source=\"n%d\" target=\"n%d\">\n", i, edge.From, edge.To)
+		fmt.Fprintf(bw, "      <data key=\"weight\">%d</data>\n", edge.Weight)
+		if st.highlight[edgeKey(g, edge.From, edge.To)] {
+			fmt.Fprintf(bw, "      <data key=\"highlight\">true</data>\n")
+		}
+		fmt.Fprintf(bw, "    </edge>\n")
+	}
+
+	fmt.Fprintf(bw, "  </graph>\n")
+	fmt.Fprintf(bw, "</graphml>\n")
+	return bw.Flush()
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
+
+func xmlEscape(s string) string {
+	return xmlEscaper.Replace(s)
+}
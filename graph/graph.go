@@ -1,10 +1,16 @@
 package graph
 
+import (
+	"fmt"
+	"sort"
+)
+
 // Очередь
 type Queue struct {
 	data []int
 }
 
+// Neighbor - сосед вершины и вес ребра до него
 type Neighbor struct {
 	To     int
 	Weight int
@@ -27,45 +33,280 @@ func (q *Queue) IsEmpty() bool {
 	return len(q.data) == 0
 }
 
-// Граф
+// ReadOnlyGraph - общий интерфейс только для чтения, по которому работают
+// обходы и поиск кратчайших путей; его реализуют Graph и CSRGraph
+type ReadOnlyGraph interface {
+	IsDirected() bool
+	NumVertices() int
+	Vertices() []int
+	HasVertex(u int) bool
+	Neighbors(u int) []Neighbor
+	InNeighbors(u int) []Neighbor
+}
+
+// Graph - взвешенный граф: неориентированный (дружба)
+// или ориентированный (подписки)
 type Graph struct {
-	adj map[int][]int
+	adj      map[int][]Neighbor // исходящие рёбра
+	in       map[int][]Neighbor // входящие рёбра, только для ориентированного графа
+	directed bool
 }
 
 func NewGraph() *Graph {
-	return &Graph{adj: make(map[int][]int)}
+	return &Graph{adj: make(map[int][]Neighbor)}
+}
+
+// NewDirectedGraph - создает ориентированный граф подписок
+func NewDirectedGraph() *Graph {
+	return &Graph{
+		adj:      make(map[int][]Neighbor),
+		in:       make(map[int][]Neighbor),
+		directed: true,
+	}
 }
 
-func (g *Graph) AddEdge(u, v int) {
+// IsDirected - true, если граф ориентированный
+func (g *Graph) IsDirected() bool {
+	return g.directed
+}
+
+// AddVertex - добавляет вершину без рёбер
+func (g *Graph) AddVertex(u int) {
 	if _, ok := g.adj[u]; !ok {
-		g.adj[u] = []int{}
+		g.adj[u] = []Neighbor{}
 	}
-	if _, ok := g.adj[v]; !ok {
-		g.adj[v] = []int{}
+	if g.directed {
+		if _, ok := g.in[u]; !ok {
+			g.in[u] = []Neighbor{}
+		}
 	}
+}
+
+// AddEdge - добавляет ребро u-v с весом weight;
+// в неориентированном графе ребро добавляется в обе стороны,
+// в ориентированном - только u -> v
+func (g *Graph) AddEdge(u, v, weight int) {
+	g.AddVertex(u)
+	g.AddVertex(v)
 
+	if HasEdge(g, u, v) {
+		return
+	}
+	g.adj[u] = append(g.adj[u], Neighbor{To: v, Weight: weight})
+	if g.directed {
+		g.in[v] = append(g.in[v], Neighbor{To: u, Weight: weight})
+	} else if u != v {
+		g.adj[v] = append(g.adj[v], Neighbor{To: u, Weight: weight})
+	}
+}
+
+// RemoveEdge - удаляет ребро u-v (в неориентированном графе в обе стороны)
+func (g *Graph) RemoveEdge(u, v int) bool {
 	if !HasEdge(g, u, v) {
-		g.adj[u] = append(g.adj[u], v)
-		g.adj[v] = append(g.adj[v], u)
+		return false
+	}
+	g.adj[u] = removeNeighbor(g.adj[u], v)
+	if g.directed {
+		g.in[v] = removeNeighbor(g.in[v], u)
+	} else if u != v {
+		g.adj[v] = removeNeighbor(g.adj[v], u)
 	}
+	return true
+}
+
+// RemoveVertex - удаляет вершину u вместе со всеми её рёбрами
+func (g *Graph) RemoveVertex(u int) bool {
+	if !g.HasVertex(u) {
+		return false
+	}
+	for _, neighbor := range g.adj[u] {
+		if g.directed {
+			g.in[neighbor.To] = removeNeighbor(g.in[neighbor.To], u)
+		} else if neighbor.To != u {
+			g.adj[neighbor.To] = removeNeighbor(g.adj[neighbor.To], u)
+		}
+	}
+	if g.directed {
+		for _, neighbor := range g.in[u] {
+			g.adj[neighbor.To] = removeNeighbor(g.adj[neighbor.To], u)
+		}
+		delete(g.in, u)
+	}
+	delete(g.adj, u)
+	return true
+}
+
+// UpdateWeight - меняет вес существующего ребра u-v
+func (g *Graph) UpdateWeight(u, v, weight int) bool {
+	if !setWeight(g.adj[u], v, weight) {
+		return false
+	}
+	if g.directed {
+		setWeight(g.in[v], u, weight)
+	} else {
+		setWeight(g.adj[v], u, weight)
+	}
+	return true
+}
+
+func removeNeighbor(neighbors []Neighbor, v int) []Neighbor {
+	for i, neighbor := range neighbors {
+		if neighbor.To == v {
+			return append(neighbors[:i], neighbors[i+1:]...)
+		}
+	}
+	return neighbors
+}
+
+func setWeight(neighbors []Neighbor, v, weight int) bool {
+	for i := range neighbors {
+		if neighbors[i].To == v {
+			neighbors[i].Weight = weight
+			return true
+		}
+	}
+	return false
+}
+
+// CheckInvariants - проверяет согласованность списков смежности:
+// каждое ребро имеет парную запись с тем же весом, нет дубликатов
+// и ссылок на отсутствующие вершины
+func (g *Graph) CheckInvariants() error {
+	for _, u := range g.Vertices() {
+		seen := make(map[int]bool)
+		for _, neighbor := range g.adj[u] {
+			v := neighbor.To
+			if seen[v] {
+				return fmt.Errorf("граф: дублирующееся ребро %d-%d", u, v)
+			}
+			seen[v] = true
+			if !g.HasVertex(v) {
+				return fmt.Errorf("граф: ребро %d-%d ведёт в отсутствующую вершину", u, v)
+			}
+			back := g.adj[v]
+			if g.directed {
+				back = g.in[v]
+			}
+			w, ok := findWeight(back, u)
+			if !ok {
+				return fmt.Errorf("граф: нет обратной записи для ребра %d-%d", u, v)
+			}
+			if w != neighbor.Weight {
+				return fmt.Errorf("граф: разные веса ребра %d-%d: %d и %d", u, v, neighbor.Weight, w)
+			}
+		}
+	}
+	if !g.directed {
+		return nil
+	}
+	for v, neighbors := range g.in {
+		if !g.HasVertex(v) {
+			return fmt.Errorf("граф: входящие рёбра у отсутствующей вершины %d", v)
+		}
+		for _, neighbor := range neighbors {
+			if !HasEdge(g, neighbor.To, v) {
+				return fmt.Errorf("граф: входящее ребро %d->%d без исходящего", neighbor.To, v)
+			}
+		}
+	}
+	return nil
+}
+
+func findWeight(neighbors []Neighbor, v int) (int, bool) {
+	for _, neighbor := range neighbors {
+		if neighbor.To == v {
+			return neighbor.Weight, true
+		}
+	}
+	return 0, false
 }
 
 func HasEdge(g *Graph, u, v int) bool {
 	for _, neighbor := range g.adj[u] {
-		if neighbor == v {
+		if neighbor.To == v {
 			return true
 		}
 	}
 	return false
 }
 
+// Weight - возвращает вес ребра u-v, если оно есть
+func (g *Graph) Weight(u, v int) (int, bool) {
+	return findWeight(g.adj[u], v)
+}
+
+// Neighbors - возвращает соседей вершины u (исходящие рёбра)
+func (g *Graph) Neighbors(u int) []Neighbor {
+	return g.adj[u]
+}
+
+// InNeighbors - возвращает вершины, из которых есть ребро в u
+func (g *Graph) InNeighbors(u int) []Neighbor {
+	if !g.directed {
+		return g.adj[u]
+	}
+	return g.in[u]
+}
+
+// OutDegree - количество исходящих рёбер
+func (g *Graph) OutDegree(u int) int {
+	return len(g.adj[u])
+}
+
+// InDegree - количество входящих рёбер
+func (g *Graph) InDegree(u int) int {
+	return len(g.InNeighbors(u))
+}
+
+// Followers - пользователи, подписанные на u
+func (g *Graph) Followers(u int) []int {
+	return neighborIDs(g.InNeighbors(u))
+}
+
+// Following - пользователи, на которых подписан u
+func (g *Graph) Following(u int) []int {
+	return neighborIDs(g.adj[u])
+}
+
+func neighborIDs(neighbors []Neighbor) []int {
+	ids := make([]int, len(neighbors))
+	for i, neighbor := range neighbors {
+		ids[i] = neighbor.To
+	}
+	return ids
+}
+
+// HasVertex - проверяет, есть ли вершина в графе
+func (g *Graph) HasVertex(u int) bool {
+	_, ok := g.adj[u]
+	return ok
+}
+
+// NumVertices - количество вершин
+func (g *Graph) NumVertices() int {
+	return len(g.adj)
+}
 
+// Vertices - возвращает все вершины по возрастанию
+func (g *Graph) Vertices() []int {
+	vertices := make([]int, 0, len(g.adj))
+	for v := range g.adj {
+		vertices = append(vertices, v)
+	}
+	sort.Ints(vertices)
+	return vertices
+}
+
+// GetAllEdges - возвращает каждое ребро один раз
+// (для неориентированного графа From <= To)
 func (g *Graph) GetAllEdges() []Edge {
 	var edges []Edge
-	for _, node := range g.Nodes {
-			for _, neighbor := range node.Neighbors {
-					edges = append(edges, Edge{From: node.ID, To: neighbor.To, Weight: neighbor.Weight})
+	for _, u := range g.Vertices() {
+		for _, neighbor := range g.adj[u] {
+			if g.directed || u <= neighbor.To {
+				edges = append(edges, Edge{From: u, To: neighbor.To, Weight: neighbor.Weight})
 			}
+		}
 	}
 	return edges
 }
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// WeightedPath - путь и его суммарный вес
+type WeightedPath struct {
+	Path   []int
+	Weight int
+}
+
+// filteredGraph - граф без части вершин и дуг
+type filteredGraph struct {
+	ReadOnlyGraph
+	removedVertices map[int]bool
+	removedArcs     map[[2]int]bool
+}
+
+func (f filteredGraph) Neighbors(u int) []Neighbor {
+	if f.removedVertices[u] {
+		return nil
+	}
+	var neighbors []Neighbor
+	for _, neighbor := range f.ReadOnlyGraph.Neighbors(u) {
+		if !f.removedVertices[neighbor.To] && !f.removedArcs[[2]int{u, neighbor.To}] {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors
+}
+
+// KShortestPaths - до k кратчайших простых путей от s до t по алгоритму Йена,
+// по возрастанию веса. Веса должны быть неотрицательными.
+func KShortestPaths(g ReadOnlyGraph, s, t, k int) []WeightedPath {
+	if k <= 0 {
+		return nil
+	}
+	path, weight, ok := AStar(g, s, t, nil)
+	if !ok {
+		return nil
+	}
+	result := []WeightedPath{{Path: path, Weight: weight}}
+
+	// кандидаты лежат в срезе, в очереди - их номера
+	var candidates []WeightedPath
+	seen := map[string]bool{pathKey(path): true}
+	pq := &PriorityQueue{}
+
+	for len(result) < k {
+		prev := result[len(result)-1].Path
+		for i := 0; i < len(prev)-1; i++ {
+			spur, root := prev[i], prev[:i+1]
+
+			f := filteredGraph{
+				ReadOnlyGraph:   g,
+				removedVertices: make(map[int]bool),
+				removedArcs:     make(map[[2]int]bool),
+			}
+			// убираем дуги, по которым уже уходили найденные пути с тем же корнем
+			for _, p := range result {
+				if len(p.Path) > i+1 && equalPrefix(p.Path, root) {
+					f.removedArcs[[2]int{spur, p.Path[i+1]}] = true
+				}
+			}
+			for _, v := range root[:i] {
+				f.removedVertices[v] = true
+			}
+
+			spurPath, _, ok := AStar(f, spur, t, nil)
+			if !ok {
+				continue
+			}
+			total := append(append([]int{}, root[:i]...), spurPath...)
+			key := pathKey(total)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, WeightedPath{Path: total, Weight: pathWeight(g, total)})
+			heap.Push(pq, &Item{vertex: len(candidates) - 1, dist: candidates[len(candidates)-1].Weight})
+		}
+
+		if pq.Len() == 0 {
+			break
+		}
+		best := heap.Pop(pq).(*Item)
+		result = append(result, candidates[best.vertex])
+	}
+	return result
+}
+
+func equalPrefix(path, prefix []int) bool {
+	for i, v := range prefix {
+		if path[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func pathKey(path []int) string {
+	return fmt.Sprint(path)
+}
+
+func pathWeight(g ReadOnlyGraph, path []int) int {
+	total := 0
+	for i := 1; i < len(path); i++ {
+		for _, neighbor := range g.Neighbors(path[i-1]) {
+			if neighbor.To == path[i] {
+				total += neighbor.Weight
+				break
+			}
+		}
+	}
+	return total
+}
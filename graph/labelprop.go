@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// LabelPropagationOptions - настройки распространения меток
+type LabelPropagationOptions struct {
+	Synchronous   bool  // все вершины обновляются по меткам прошлого шага
+	Workers       int   // больше 1 - синхронный режим, вершины делятся между горутинами
+	Weighted      bool  // голос соседа равен весу дружбы
+	Seed          int64 // зерно для порядка обхода и разрешения ничьих
+	MaxIterations int   // по умолчанию 100
+}
+
+// LabelPropagation - поиск общин распространением меток: каждая вершина
+// принимает метку, самую частую (или самую тяжёлую) среди соседей.
+// При одинаковом Seed результат воспроизводим, в том числе в
+// параллельном режиме. Возвращает число общин и общину каждой
+// вершины (с 1), как ConnectedComponents.
+func LabelPropagation(g ReadOnlyGraph, opts LabelPropagationOptions) (count int, comm map[int]int) {
+	ids := g.Vertices()
+	n := len(ids)
+	index := make(map[int]int, n)
+	for i, id := range ids {
+		index[id] = i
+	}
+	adj := make([][]Neighbor, n)
+	for i, id := range ids {
+		for _, neighbor := range g.Neighbors(id) {
+			w := 1
+			if opts.Weighted {
+				w = neighbor.Weight
+			}
+			adj[i] = append(adj[i], Neighbor{To: index[neighbor.To], Weight: w})
+		}
+	}
+
+	maxIterations := opts.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = 100
+	}
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = i
+	}
+
+	if opts.Synchronous || opts.Workers > 1 {
+		labels = syncLabelPropagation(adj, labels, opts, maxIterations)
+	} else {
+		asyncLabelPropagation(adj, labels, opts, maxIterations)
+	}
+
+	renumbered, count := renumber(labels)
+	comm = make(map[int]int, n)
+	for i, id := range ids {
+		comm[id] = renumbered[i] + 1
+	}
+	return count, comm
+}
+
+func asyncLabelPropagation(adj [][]Neighbor, labels []int, opts LabelPropagationOptions, maxIterations int) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	votes := make(map[int]int)
+	for iter := 0; iter < maxIterations; iter++ {
+		changed := false
+		for _, v := range rng.Perm(len(adj)) {
+			label := bestLabel(adj[v], labels, labels[v], opts.Seed, iter, v, votes)
+			if label != labels[v] {
+				labels[v] = label
+				changed = true
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+func syncLabelPropagation(adj [][]Neighbor, labels []int, opts LabelPropagationOptions, maxIterations int) []int {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	n := len(adj)
+	next := make([]int, n)
+	chunk := (n + workers - 1) / workers
+
+	for iter := 0; iter < maxIterations; iter++ {
+		changed := make([]bool, workers)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			lo, hi := w*chunk, minInt((w+1)*chunk, n)
+			if lo >= hi {
+				continue
+			}
+			wg.Add(1)
+			go func(w, lo, hi int) {
+				defer wg.Done()
+				votes := make(map[int]int)
+				for v := lo; v < hi; v++ {
+					next[v] = bestLabel(adj[v], labels, labels[v], opts.Seed, iter, v, votes)
+					if next[v] != labels[v] {
+						changed[w] = true
+					}
+				}
+			}(w, lo, hi)
+		}
+		wg.Wait()
+
+		labels, next = next, labels
+		done := true
+		for _, c := range changed {
+			done = done && !c
+		}
+		if done {
+			break
+		}
+	}
+	return labels
+}
+
+// bestLabel - метка с наибольшим числом голосов соседей. Текущая метка
+// сохраняется, если она среди лучших; остальные ничьи разрешаются
+// детерминированным хешем от зерна, шага и вершины.
+func bestLabel(neighbors []Neighbor, labels []int, current int, seed int64, iter, v int, votes map[int]int) int {
+	if len(neighbors) == 0 {
+		return current
+	}
+	for label := range votes {
+		delete(votes, label)
+	}
+	for _, neighbor := range neighbors {
+		votes[labels[neighbor.To]] += neighbor.Weight
+	}
+
+	maxVotes := votes[current]
+	for _, count := range votes {
+		if count > maxVotes {
+			maxVotes = count
+		}
+	}
+	if votes[current] == maxVotes {
+		return current
+	}
+
+	best, bestHash := current, uint64(0)
+	for label, count := range votes {
+		if count != maxVotes {
+			continue
+		}
+		if h := mixHash(seed, iter, v, label); best == current || h < bestHash {
+			best, bestHash = label, h
+		}
+	}
+	return best
+}
+
+// mixHash - перемешивание splitmix64
+func mixHash(seed int64, iter, v, label int) uint64 {
+	x := uint64(seed) ^ uint64(iter)*0x9e3779b97f4a7c15 ^ uint64(v)*0xbf58476d1ce4e5b9 ^ uint64(label)*0x94d049bb133111eb
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
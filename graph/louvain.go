@@ -0,0 +1,205 @@
+package graph
+
+// LouvainResult - результат алгоритма Лувена
+type LouvainResult struct {
+	Community  map[int]int   // итоговая община пользователя (с 1)
+	Levels     []map[int]int // община пользователя после каждого уровня
+	Modularity float64
+}
+
+// louvainGraph - сжатый взвешенный граф уровня: вершины 0..n-1,
+// loop[i] - суммарный вес рёбер внутри вершины
+type louvainGraph struct {
+	adj  [][]louvainArc
+	loop []float64
+}
+
+type louvainArc struct {
+	to     int
+	weight float64
+}
+
+func (lg *louvainGraph) strength(i int) float64 {
+	k := 2 * lg.loop[i]
+	for _, arc := range lg.adj[i] {
+		k += arc.weight
+	}
+	return k
+}
+
+// Modularity - модульность разбиения comm неориентированного графа
+func Modularity(g ReadOnlyGraph, comm map[int]int) float64 {
+	var m2 float64
+	in := make(map[int]float64)
+	tot := make(map[int]float64)
+	for _, u := range g.Vertices() {
+		for _, neighbor := range g.Neighbors(u) {
+			w := float64(neighbor.Weight)
+			if neighbor.To == u {
+				w *= 2 // петля входит в степень дважды
+			}
+			m2 += w
+			tot[comm[u]] += w
+			if comm[u] == comm[neighbor.To] {
+				in[comm[u]] += w
+			}
+		}
+	}
+	if m2 == 0 {
+		return 0
+	}
+	q := 0.0
+	for c, t := range tot {
+		q += in[c]/m2 - (t/m2)*(t/m2)
+	}
+	return q
+}
+
+// Louvain - поиск общин жадной оптимизацией модульности по весам дружбы.
+// Вершины обходятся по возрастанию ID, поэтому результат детерминирован.
+// Граф считается неориентированным, веса - положительными.
+func Louvain(g ReadOnlyGraph) *LouvainResult {
+	ids := g.Vertices()
+	index := make(map[int]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	lg := &louvainGraph{adj: make([][]louvainArc, len(ids)), loop: make([]float64, len(ids))}
+	for i, id := range ids {
+		for _, neighbor := range g.Neighbors(id) {
+			if neighbor.To == id {
+				lg.loop[i] += float64(neighbor.Weight)
+				continue
+			}
+			lg.adj[i] = append(lg.adj[i], louvainArc{to: index[neighbor.To], weight: float64(neighbor.Weight)})
+		}
+	}
+
+	res := &LouvainResult{}
+	// member[i] - вершина текущего уровня, в которую попал пользователь ids[i]
+	member := make([]int, len(ids))
+	for i := range member {
+		member[i] = i
+	}
+
+	for {
+		comm, moved := lg.moveNodes()
+		if !moved && len(res.Levels) > 0 {
+			break
+		}
+		count := 0
+		comm, count = renumber(comm)
+		for i := range member {
+			member[i] = comm[member[i]]
+		}
+
+		level := make(map[int]int, len(ids))
+		for i, id := range ids {
+			level[id] = member[i] + 1
+		}
+		res.Levels = append(res.Levels, level)
+		if !moved || count == len(lg.adj) {
+			break
+		}
+		lg = lg.aggregate(comm, count)
+	}
+
+	res.Community = res.Levels[len(res.Levels)-1]
+	res.Modularity = Modularity(g, res.Community)
+	return res
+}
+
+// moveNodes - первая фаза: переносим вершины в соседние общины, пока
+// модульность растёт
+func (lg *louvainGraph) moveNodes() ([]int, bool) {
+	n := len(lg.adj)
+	comm := make([]int, n)
+	tot := make([]float64, n)
+	k := make([]float64, n)
+	var m2 float64
+	for i := 0; i < n; i++ {
+		comm[i] = i
+		k[i] = lg.strength(i)
+		tot[i] = k[i]
+		m2 += k[i]
+	}
+	if m2 == 0 {
+		return comm, false
+	}
+
+	moved := false
+	links := make(map[int]float64)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n; i++ {
+			for c := range links {
+				delete(links, c)
+			}
+			for _, arc := range lg.adj[i] {
+				links[comm[arc.to]] += arc.weight
+			}
+
+			old := comm[i]
+			tot[old] -= k[i]
+			// прирост модульности от переноса i в общину c (с точностью до множителя)
+			gain := func(c int) float64 {
+				return links[c] - tot[c]*k[i]/m2
+			}
+			best, bestGain := old, gain(old)
+			for c := range links {
+				if gc := gain(c); gc > bestGain || (gc == bestGain && c < best) {
+					best, bestGain = c, gc
+				}
+			}
+			tot[best] += k[i]
+			comm[i] = best
+			if best != old {
+				improved, moved = true, true
+			}
+		}
+	}
+	return comm, moved
+}
+
+// aggregate - вторая фаза: сжимаем каждую общину в одну вершину
+func (lg *louvainGraph) aggregate(comm []int, count int) *louvainGraph {
+	next := &louvainGraph{adj: make([][]louvainArc, count), loop: make([]float64, count)}
+	weights := make([]map[int]float64, count)
+	for c := range weights {
+		weights[c] = make(map[int]float64)
+	}
+	for i, arcs := range lg.adj {
+		next.loop[comm[i]] += lg.loop[i]
+		for _, arc := range arcs {
+			if comm[i] == comm[arc.to] {
+				// внутреннее ребро встречается дважды, берём половину
+				next.loop[comm[i]] += arc.weight / 2
+				continue
+			}
+			weights[comm[i]][comm[arc.to]] += arc.weight
+		}
+	}
+	for c := range weights {
+		for to := 0; to < count; to++ {
+			if w, ok := weights[c][to]; ok {
+				next.adj[c] = append(next.adj[c], louvainArc{to: to, weight: w})
+			}
+		}
+	}
+	return next
+}
+
+// renumber - перенумеровывает общины в 0..count-1 по порядку появления
+func renumber(comm []int) ([]int, int) {
+	ids := make(map[int]int)
+	out := make([]int, len(comm))
+	for i, c := range comm {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		out[i] = id
+	}
+	return out, len(ids)
+}
@@ -1,6 +1,11 @@
 package graph
 
-import "sort"
+import (
+	"container/heap"
+	"runtime"
+	"sort"
+	"sync"
+)
 
 // DisjointSet
 type DisjointSet struct {
@@ -52,19 +57,244 @@ type Edge struct {
 	Weight int
 }
 
-// MST
+// MST - алгоритм Краскала для вершин 0..n-1. На несвязном графе
+// возвращает объединение деревьев всех компонент; для произвольных ID
+// и разбиения по компонентам используйте Kruskal.
 func MST(n int, edges []Edge) (mst []Edge, totalWeight int) {
 	sort.Slice(edges, func(i, j int) bool {
-		return edges[i].w < edges[j].w
+		return edges[i].Weight < edges[j].Weight
 	})
 
 	ds := NewDisjointSet(n)
 	for _, edge := range edges {
-		if ds.Find(edge.u) != ds.Find(edge.v) {
-			ds.Union(edge.u, edge.v)
+		if ds.Find(edge.From) != ds.Find(edge.To) {
+			ds.Union(edge.From, edge.To)
 			mst = append(mst, edge)
-			totalWeight += edge.w
+			totalWeight += edge.Weight
 		}
 	}
 	return mst, totalWeight
 }
+
+// SpanningMode - минимальное или максимальное остовное дерево
+type SpanningMode int
+
+const (
+	MinimumSpanning SpanningMode = iota
+	MaximumSpanning              // "самый крепкий каркас дружбы"
+)
+
+// less - сравнение весов с учётом режима
+func (m SpanningMode) less(a, b int) bool {
+	if m == MaximumSpanning {
+		return a > b
+	}
+	return a < b
+}
+
+// SpanningTree - остовное дерево одной компоненты связности
+type SpanningTree struct {
+	Vertices []int
+	Edges    []Edge
+	Weight   int
+}
+
+// SpanningForest - остовный лес: по дереву на каждую компоненту
+type SpanningForest struct {
+	Trees       []SpanningTree
+	TotalWeight int
+}
+
+// Edges - все рёбра леса
+func (f *SpanningForest) Edges() []Edge {
+	var edges []Edge
+	for _, tree := range f.Trees {
+		edges = append(edges, tree.Edges...)
+	}
+	return edges
+}
+
+// newSpanningForest - раскладывает рёбра леса (в номерах 0..n-1) по компонентам
+func newSpanningForest(ids []int, edges []Edge) *SpanningForest {
+	ds := NewDisjointSet(len(ids))
+	for _, edge := range edges {
+		ds.Union(edge.From, edge.To)
+	}
+
+	forest := &SpanningForest{}
+	treeOf := make(map[int]int)
+	for i, id := range ids {
+		root := ds.Find(i)
+		t, ok := treeOf[root]
+		if !ok {
+			t = len(forest.Trees)
+			treeOf[root] = t
+			forest.Trees = append(forest.Trees, SpanningTree{})
+		}
+		forest.Trees[t].Vertices = append(forest.Trees[t].Vertices, id)
+	}
+	for _, edge := range edges {
+		t := treeOf[ds.Find(edge.From)]
+		forest.Trees[t].Edges = append(forest.Trees[t].Edges, Edge{From: ids[edge.From], To: ids[edge.To], Weight: edge.Weight})
+		forest.Trees[t].Weight += edge.Weight
+		forest.TotalWeight += edge.Weight
+	}
+	return forest
+}
+
+// Kruskal - остовный лес алгоритмом Краскала
+func Kruskal(g *Graph, mode SpanningMode) *SpanningForest {
+	ids, edges := indexedEdges(g)
+	sort.SliceStable(edges, func(i, j int) bool {
+		return mode.less(edges[i].Weight, edges[j].Weight)
+	})
+
+	ds := NewDisjointSet(len(ids))
+	var tree []Edge
+	for _, edge := range edges {
+		if ds.Union(edge.From, edge.To) {
+			tree = append(tree, edge)
+		}
+	}
+	return newSpanningForest(ids, tree)
+}
+
+// Prim - остовный лес алгоритмом Прима с кучей; запускается заново
+// из каждой ещё не покрытой вершины
+func Prim(g *Graph, mode SpanningMode) *SpanningForest {
+	ids := g.Vertices()
+	index := make(map[int]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	// ключ в куче - вес ребра, для максимального дерева со знаком минус
+	key := func(w int) int {
+		if mode == MaximumSpanning {
+			return -w
+		}
+		return w
+	}
+
+	inTree := make([]bool, len(ids))
+	best := make([]int, len(ids))
+	from := make([]int, len(ids))
+	for i := range from {
+		from[i] = -1
+	}
+	var tree []Edge
+
+	for start := range ids {
+		if inTree[start] {
+			continue
+		}
+		pq := &PriorityQueue{}
+		heap.Push(pq, &Item{vertex: start, dist: 0})
+
+		for pq.Len() > 0 {
+			item := heap.Pop(pq).(*Item)
+			u := item.vertex
+			if inTree[u] || (from[u] >= 0 && item.dist != key(best[u])) {
+				continue
+			}
+			inTree[u] = true
+			if from[u] >= 0 {
+				tree = append(tree, Edge{From: from[u], To: u, Weight: best[u]})
+			}
+			for _, neighbor := range g.adj[ids[u]] {
+				v := index[neighbor.To]
+				if inTree[v] {
+					continue
+				}
+				if from[v] < 0 || mode.less(neighbor.Weight, best[v]) {
+					best[v], from[v] = neighbor.Weight, u
+					heap.Push(pq, &Item{vertex: v, dist: key(neighbor.Weight)})
+				}
+			}
+		}
+	}
+	return newSpanningForest(ids, tree)
+}
+
+// Boruvka - остовный лес алгоритмом Борувки. Поиск лучшего ребра для
+// каждой компоненты в раунде делится между workers горутинами
+// (workers <= 0 - по числу процессоров).
+func Boruvka(g *Graph, mode SpanningMode, workers int) *SpanningForest {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	ids, edges := indexedEdges(g)
+	n := len(ids)
+	ds := NewDisjointSet(n)
+	comp := make([]int, n)
+
+	// better - строгий порядок рёбер: по весу, затем по номеру,
+	// иначе при равных весах могут появиться циклы
+	better := func(a, b int) bool {
+		if b < 0 {
+			return true
+		}
+		if edges[a].Weight != edges[b].Weight {
+			return mode.less(edges[a].Weight, edges[b].Weight)
+		}
+		return a < b
+	}
+
+	var tree []Edge
+	for {
+		for i := 0; i < n; i++ {
+			comp[i] = ds.Find(i)
+		}
+
+		// каждая горутина ищет лучшие рёбра в своей части списка
+		local := make([][]int, workers)
+		var wg sync.WaitGroup
+		chunk := (len(edges) + workers - 1) / workers
+		for w := 0; w < workers; w++ {
+			lo, hi := w*chunk, minInt((w+1)*chunk, len(edges))
+			cheapest := make([]int, n)
+			for i := range cheapest {
+				cheapest[i] = -1
+			}
+			local[w] = cheapest
+			if lo >= hi {
+				continue
+			}
+			wg.Add(1)
+			go func(lo, hi int, cheapest []int) {
+				defer wg.Done()
+				for e := lo; e < hi; e++ {
+					cu, cv := comp[edges[e].From], comp[edges[e].To]
+					if cu == cv {
+						continue
+					}
+					if better(e, cheapest[cu]) {
+						cheapest[cu] = e
+					}
+					if better(e, cheapest[cv]) {
+						cheapest[cv] = e
+					}
+				}
+			}(lo, hi, cheapest)
+		}
+		wg.Wait()
+
+		added := false
+		for c := 0; c < n; c++ {
+			best := -1
+			for w := 0; w < workers; w++ {
+				if e := local[w][c]; e >= 0 && better(e, best) {
+					best = e
+				}
+			}
+			if best >= 0 && ds.Union(edges[best].From, edges[best].To) {
+				tree = append(tree, edges[best])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return newSpanningForest(ids, tree)
+}
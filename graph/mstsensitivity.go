@@ -0,0 +1,139 @@
+package graph
+
+import "sort"
+
+// EdgeSensitivity - насколько можно изменить вес ребра, не меняя MST.
+// Для ребра дерева Slack - на сколько его можно увеличить,
+// для ребра вне дерева - на сколько уменьшить. Replacement - ребро,
+// которое поменяется с ним местами на границе; Slack = Infinity,
+// если такого ребра нет (мост).
+type EdgeSensitivity struct {
+	Edge           Edge
+	InTree         bool
+	Slack          int
+	Replacement    Edge
+	HasReplacement bool
+}
+
+// mstWithTree - MST (или лес) графа, корневое дерево по нему и рёбра вне дерева
+func mstWithTree(g *Graph) ([]Edge, int, *Tree, []Edge) {
+	ids, edges := indexedEdges(g)
+	mst, total := MST(len(ids), edges)
+
+	inTree := make(map[[2]int]bool)
+	tree := make([]Edge, len(mst))
+	for i, edge := range mst {
+		tree[i] = Edge{From: ids[edge.From], To: ids[edge.To], Weight: edge.Weight}
+		inTree[edgeKey(g, edge.From, edge.To)] = true
+	}
+	var rest []Edge
+	for _, edge := range edges {
+		if !inTree[edgeKey(g, edge.From, edge.To)] && edge.From != edge.To {
+			rest = append(rest, Edge{From: ids[edge.From], To: ids[edge.To], Weight: edge.Weight})
+		}
+	}
+	return tree, total, NewTree(tree), rest
+}
+
+// SecondBestMST - самое лёгкое остовное дерево, отличное от MST:
+// одно ребро вне дерева заменяет самое тяжёлое ребро на своём цикле.
+// Возвращает false, если другого остовного дерева нет.
+// Только для неориентированного графа.
+func SecondBestMST(g *Graph) ([]Edge, int, bool) {
+	if g.directed {
+		return nil, 0, false
+	}
+	tree, total, t, rest := mstWithTree(g)
+
+	bestCost := Infinity
+	var add, remove Edge
+	for _, edge := range rest {
+		heaviest, ok := t.PathMax(edge.From, edge.To)
+		if !ok {
+			continue
+		}
+		if cost := total + edge.Weight - heaviest.Weight; cost < bestCost {
+			bestCost, add, remove = cost, edge, heaviest
+		}
+	}
+	if bestCost == Infinity {
+		return nil, 0, false
+	}
+
+	removeKey := edgeKey(g, remove.From, remove.To)
+	second := []Edge{add}
+	for _, edge := range tree {
+		if edgeKey(g, edge.From, edge.To) != removeKey {
+			second = append(second, edge)
+		}
+	}
+	return second, bestCost, true
+}
+
+// MSTSensitivity - чувствительность каждого ребра графа относительно MST.
+// Только для неориентированного графа.
+func MSTSensitivity(g *Graph) []EdgeSensitivity {
+	if g.directed {
+		return nil
+	}
+	tree, _, t, rest := mstWithTree(g)
+	var result []EdgeSensitivity
+
+	// ребро вне дерева: можно уменьшать до веса самого тяжёлого ребра цикла
+	for _, edge := range rest {
+		s := EdgeSensitivity{Edge: edge, Slack: Infinity}
+		if heaviest, ok := t.PathMax(edge.From, edge.To); ok {
+			s.Slack = edge.Weight - heaviest.Weight
+			s.Replacement, s.HasReplacement = heaviest, true
+		}
+		result = append(result, s)
+	}
+
+	// ребро дерева: можно увеличивать до самого лёгкого ребра вне дерева,
+	// чей цикл его покрывает. Рёбра вне дерева перебираем по возрастанию
+	// веса, каждое ребро дерева назначаем один раз, перепрыгивая
+	// уже назначенные через систему непересекающихся множеств.
+	sort.SliceStable(rest, func(i, j int) bool { return rest[i].Weight < rest[j].Weight })
+	n := len(t.ids)
+	cover := make([]int, n)
+	jump := make([]int, n)
+	for v := 0; v < n; v++ {
+		cover[v] = -1
+		jump[v] = v
+	}
+	var find func(v int) int
+	find = func(v int) int {
+		if jump[v] != v {
+			jump[v] = find(jump[v])
+		}
+		return jump[v]
+	}
+	for k, edge := range rest {
+		u, v, ok := t.pair(edge.From, edge.To)
+		if !ok {
+			continue
+		}
+		a, _ := t.lca(u, v)
+		for _, x := range []int{u, v} {
+			for x = find(x); t.depth[x] > t.depth[a]; x = find(x) {
+				cover[x] = k
+				jump[x] = t.parent[x]
+			}
+		}
+	}
+
+	for _, edge := range tree {
+		// ребро дерева хранится в дочерней вершине
+		child := t.index[edge.To]
+		if t.parent[child] != t.index[edge.From] {
+			child = t.index[edge.From]
+		}
+		s := EdgeSensitivity{Edge: edge, InTree: true, Slack: Infinity}
+		if k := cover[child]; k >= 0 {
+			s.Slack = rest[k].Weight - edge.Weight
+			s.Replacement, s.HasReplacement = rest[k], true
+		}
+		result = append(result, s)
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"math"
+	"sync"
+)
+
+// PageRankOptions - настройки PageRank
+type PageRankOptions struct {
+	Damping       float64 // вероятность перейти по ребру, по умолчанию 0.85
+	Tolerance     float64 // порог сходимости по сумме изменений, по умолчанию 1e-9
+	MaxIterations int     // по умолчанию 100
+	Weighted      bool    // переход пропорционален весу ребра
+	Workers       int     // больше 1 - итерации считаются параллельно
+}
+
+// PageRank - влиятельность пользователей. В ориентированном графе
+// подписок вес передаётся по направлению подписки. Вес вершин без
+// исходящих рёбер распределяется равномерно.
+func PageRank(g ReadOnlyGraph, opts PageRankOptions) map[int]float64 {
+	return pageRank(g, nil, opts)
+}
+
+// PersonalizedPageRank - PageRank с телепортацией только в вершины seeds:
+// влиятельность относительно заданной группы пользователей.
+// Возвращает nil, если ни одной вершины из seeds нет в графе.
+func PersonalizedPageRank(g ReadOnlyGraph, seeds []int, opts PageRankOptions) map[int]float64 {
+	if len(seeds) == 0 {
+		return nil
+	}
+	return pageRank(g, seeds, opts)
+}
+
+func pageRank(g ReadOnlyGraph, seeds []int, opts PageRankOptions) map[int]float64 {
+	damping := opts.Damping
+	if damping <= 0 || damping >= 1 {
+		damping = 0.85
+	}
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = 1e-9
+	}
+	maxIterations := opts.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = 100
+	}
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	ids := g.Vertices()
+	n := len(ids)
+	index := make(map[int]int, n)
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	// вектор телепортации: равномерный или по seeds
+	teleport := make([]float64, n)
+	if seeds == nil {
+		for i := range teleport {
+			teleport[i] = 1 / float64(n)
+		}
+	} else {
+		valid := 0
+		for _, s := range seeds {
+			if i, ok := index[s]; ok && teleport[i] == 0 {
+				teleport[i] = 1
+				valid++
+			}
+		}
+		if valid == 0 {
+			return nil
+		}
+		for i := range teleport {
+			teleport[i] /= float64(valid)
+		}
+	}
+
+	edgeWeight := func(neighbor Neighbor) float64 {
+		if opts.Weighted {
+			return float64(neighbor.Weight)
+		}
+		return 1
+	}
+	out := make([]float64, n)
+	in := make([][]Neighbor, n)
+	for i, id := range ids {
+		for _, neighbor := range g.Neighbors(id) {
+			out[i] += edgeWeight(neighbor)
+		}
+		for _, neighbor := range g.InNeighbors(id) {
+			in[i] = append(in[i], Neighbor{To: index[neighbor.To], Weight: neighbor.Weight})
+		}
+	}
+
+	rank := make([]float64, n)
+	copy(rank, teleport)
+	next := make([]float64, n)
+	chunk := (n + workers - 1) / workers
+
+	for iter := 0; iter < maxIterations; iter++ {
+		dangling := 0.0
+		for i := 0; i < n; i++ {
+			if out[i] <= 0 {
+				dangling += rank[i]
+			}
+		}
+
+		// каждая горутина считает новые ранги своей части вершин
+		diffs := make([]float64, workers)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			lo, hi := w*chunk, minInt((w+1)*chunk, n)
+			if lo >= hi {
+				continue
+			}
+			wg.Add(1)
+			go func(w, lo, hi int) {
+				defer wg.Done()
+				for v := lo; v < hi; v++ {
+					sum := 0.0
+					for _, neighbor := range in[v] {
+						u := neighbor.To
+						if out[u] > 0 {
+							sum += rank[u] * edgeWeight(neighbor) / out[u]
+						}
+					}
+					next[v] = (1-damping)*teleport[v] + damping*(sum+dangling*teleport[v])
+					diffs[w] += math.Abs(next[v] - rank[v])
+				}
+			}(w, lo, hi)
+		}
+		wg.Wait()
+
+		rank, next = next, rank
+		diff := 0.0
+		for _, d := range diffs {
+			diff += d
+		}
+		if diff < tolerance {
+			break
+		}
+	}
+
+	result := make(map[int]float64, n)
+	for i, id := range ids {
+		result[id] = rank[i]
+	}
+	return result
+}
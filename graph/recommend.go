@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// ScoreMethod - способ оценки кандидата в друзья
+type ScoreMethod int
+
+const (
+	CommonNeighbors ScoreMethod = iota // число общих друзей
+	Jaccard                            // общие друзья / объединение друзей
+	AdamicAdar                         // общие друзья с весом 1/log(степень)
+)
+
+// RecommendOptions - настройки рекомендаций
+type RecommendOptions struct {
+	Method   ScoreMethod
+	Weighted bool // учитывать веса дружбы
+}
+
+// Recommendation - рекомендованный пользователь, его оценка и общие друзья
+type Recommendation struct {
+	User   int
+	Score  float64
+	Mutual []int
+}
+
+// Recommend - рекомендует до k пользователей на расстоянии двух шагов от user,
+// с которыми у него ещё нет ребра. Для ориентированного графа используются
+// исходящие рёбра (на кого подписаны). При k <= 0 возвращаются все кандидаты.
+func Recommend(g ReadOnlyGraph, user, k int, opts RecommendOptions) []Recommendation {
+	userWeights := neighborWeights(g, user)
+
+	mutual := make(map[int][]int)
+	for _, z := range g.Neighbors(user) {
+		if z.To == user {
+			continue
+		}
+		for _, v := range g.Neighbors(z.To) {
+			if v.To == user {
+				continue
+			}
+			if _, ok := userWeights[v.To]; ok {
+				continue
+			}
+			mutual[v.To] = append(mutual[v.To], z.To)
+		}
+	}
+
+	result := make([]Recommendation, 0, len(mutual))
+	for v, friends := range mutual {
+		sort.Ints(friends)
+		result = append(result, Recommendation{
+			User:   v,
+			Score:  linkScore(g, user, v, userWeights, friends, opts),
+			Mutual: friends,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].User < result[j].User
+	})
+	if k > 0 && len(result) > k {
+		result = result[:k]
+	}
+	return result
+}
+
+func neighborWeights(g ReadOnlyGraph, u int) map[int]float64 {
+	weights := make(map[int]float64)
+	for _, neighbor := range g.Neighbors(u) {
+		if neighbor.To != u {
+			weights[neighbor.To] = float64(neighbor.Weight)
+		}
+	}
+	return weights
+}
+
+// linkScore - оценка пары (u, v) по их общим друзьям mutual
+func linkScore(g ReadOnlyGraph, u, v int, uWeights map[int]float64, mutual []int, opts RecommendOptions) float64 {
+	// вклад общего друга z: 1 или среднее весов рёбер u-z и z-v
+	contribution := func(z int) float64 {
+		if !opts.Weighted {
+			return 1
+		}
+		wzv, _ := neighborWeight(g, z, v)
+		return (uWeights[z] + wzv) / 2
+	}
+
+	switch opts.Method {
+	case Jaccard:
+		vWeights := neighborWeights(g, v)
+		if !opts.Weighted {
+			union := len(uWeights) + len(vWeights) - len(mutual)
+			return float64(len(mutual)) / float64(union)
+		}
+		// взвешенный Жаккар: сумма минимумов / сумма максимумов
+		var num, den float64
+		for z, wu := range uWeights {
+			wv := vWeights[z]
+			num += math.Min(wu, wv)
+			den += math.Max(wu, wv)
+		}
+		for z, wv := range vWeights {
+			if _, ok := uWeights[z]; !ok {
+				den += wv
+			}
+		}
+		if den == 0 {
+			return 0
+		}
+		return num / den
+
+	case AdamicAdar:
+		score := 0.0
+		for _, z := range mutual {
+			degree := float64(len(g.Neighbors(z)))
+			if opts.Weighted {
+				degree = 0
+				for _, neighbor := range g.Neighbors(z) {
+					degree += float64(neighbor.Weight)
+				}
+			}
+			if degree > 1 {
+				score += contribution(z) / math.Log(degree)
+			}
+		}
+		return score
+
+	default:
+		score := 0.0
+		for _, z := range mutual {
+			score += contribution(z)
+		}
+		return score
+	}
+}
+
+func neighborWeight(g ReadOnlyGraph, u, v int) (float64, bool) {
+	for _, neighbor := range g.Neighbors(u) {
+		if neighbor.To == v {
+			return float64(neighbor.Weight), true
+		}
+	}
+	return 0, false
+}
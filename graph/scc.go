@@ -0,0 +1,178 @@
+package graph
+
+// Компоненты сильной связности. Обе реализации итеративные и нумеруют
+// компоненты с 1 в топологическом порядке графа конденсации: рёбра
+// между компонентами идут только от меньшего номера к большему.
+
+// TarjanSCC - компоненты сильной связности алгоритмом Тарьяна
+func TarjanSCC(g ReadOnlyGraph) (count int, comp map[int]int) {
+	index := make(map[int]int)
+	low := make(map[int]int)
+	onStack := make(map[int]bool)
+	var stack []int
+	var sccs [][]int
+
+	// кадр явного стека вместо рекурсии
+	type frame struct {
+		v    int
+		next int // номер следующего соседа
+	}
+
+	for _, s := range g.Vertices() {
+		if _, seen := index[s]; seen {
+			continue
+		}
+		frames := []frame{{v: s}}
+		index[s], low[s] = len(index), len(index)
+		stack = append(stack, s)
+		onStack[s] = true
+
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			neighbors := g.Neighbors(f.v)
+			if f.next < len(neighbors) {
+				w := neighbors[f.next].To
+				f.next++
+				if _, seen := index[w]; !seen {
+					index[w], low[w] = len(index), len(index)
+					stack = append(stack, w)
+					onStack[w] = true
+					frames = append(frames, frame{v: w})
+				} else if onStack[w] && index[w] < low[f.v] {
+					low[f.v] = index[w]
+				}
+				continue
+			}
+
+			v := f.v
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				if p := frames[len(frames)-1].v; low[v] < low[p] {
+					low[p] = low[v]
+				}
+			}
+			if low[v] == index[v] {
+				var scc []int
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					scc = append(scc, w)
+					if w == v {
+						break
+					}
+				}
+				sccs = append(sccs, scc)
+			}
+		}
+	}
+
+	// Тарьян находит компоненты в обратном топологическом порядке
+	comp = make(map[int]int)
+	count = len(sccs)
+	for i, scc := range sccs {
+		for _, v := range scc {
+			comp[v] = count - i
+		}
+	}
+	return count, comp
+}
+
+// KosarajuSCC - компоненты сильной связности алгоритмом Косарайю
+func KosarajuSCC(g ReadOnlyGraph) (count int, comp map[int]int) {
+	// первый проход: порядок выхода вершин в прямом графе
+	visited := make(map[int]bool)
+	var finished []int
+	type frame struct {
+		v    int
+		next int
+	}
+	for _, s := range g.Vertices() {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		frames := []frame{{v: s}}
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			neighbors := g.Neighbors(f.v)
+			if f.next < len(neighbors) {
+				w := neighbors[f.next].To
+				f.next++
+				if !visited[w] {
+					visited[w] = true
+					frames = append(frames, frame{v: w})
+				}
+				continue
+			}
+			finished = append(finished, f.v)
+			frames = frames[:len(frames)-1]
+		}
+	}
+
+	// второй проход по обратному графу в порядке убывания времени выхода
+	rev := reversedGraph{g}
+	comp = make(map[int]int)
+	for i := len(finished) - 1; i >= 0; i-- {
+		s := finished[i]
+		if _, ok := comp[s]; ok {
+			continue
+		}
+		count++
+		comp[s] = count
+		stack := Stack{}
+		stack.Push(s)
+		for !stack.IsEmpty() {
+			v, _ := stack.Pop()
+			for _, neighbor := range rev.Neighbors(v) {
+				if _, ok := comp[neighbor.To]; !ok {
+					comp[neighbor.To] = count
+					stack.Push(neighbor.To)
+				}
+			}
+		}
+	}
+	return count, comp
+}
+
+// Condensation - граф конденсации: каждая компонента сильной связности
+// сжата в вершину
+type Condensation struct {
+	Count   int
+	Comp    map[int]int // вершина -> номер компоненты (1..Count)
+	Members [][]int     // Members[c-1] - вершины компоненты c по возрастанию
+	DAG     *Graph      // ориентированный ациклический граф на 1..Count, вес - число дуг
+	Order   []int       // топологический порядок компонент
+}
+
+// Condense - строит граф конденсации по алгоритму Тарьяна
+func Condense(g ReadOnlyGraph) *Condensation {
+	count, comp := TarjanSCC(g)
+	c := &Condensation{
+		Count:   count,
+		Comp:    comp,
+		Members: make([][]int, count),
+		DAG:     NewDirectedGraph(),
+		Order:   make([]int, count),
+	}
+	for i := range c.Order {
+		c.Order[i] = i + 1
+		c.DAG.AddVertex(i + 1)
+	}
+	for _, u := range g.Vertices() {
+		cu := comp[u]
+		c.Members[cu-1] = append(c.Members[cu-1], u)
+		for _, neighbor := range g.Neighbors(u) {
+			cv := comp[neighbor.To]
+			if cu == cv {
+				continue
+			}
+			if w, ok := c.DAG.Weight(cu, cv); ok {
+				c.DAG.UpdateWeight(cu, cv, w+1)
+			} else {
+				c.DAG.AddEdge(cu, cv, 1)
+			}
+		}
+	}
+	return c
+}
@@ -2,7 +2,7 @@ package graph
 
 import (
 	"container/heap"
-	"math"
+	"sort"
 )
 
 // вершина в приоритетной очереди
@@ -30,57 +30,206 @@ func (pq *PriorityQueue) Pop() interface{} {
 	return item
 }
 
-// Dijkstra
-func Dijkstra(g *Graph, start int) ([]int, []int) {
-	n := len(g.adj)
-	dist := make([]int, n)
-	parent := make([]int, n)
-	for i := 0; i < n; i++ {
-		dist[i] = int(^uint(0) >> 1)
-		parent[i] = -1
+// Infinity - расстояние до недостижимой вершины
+const Infinity = int(^uint(0) >> 1)
+
+// ShortestPathTree - дерево кратчайших путей из одной вершины.
+// Хранит только достигнутые вершины, поэтому ID могут быть любыми.
+type ShortestPathTree struct {
+	Source int
+	dist   map[int]int
+	parent map[int]int
+}
+
+func newShortestPathTree(source int) *ShortestPathTree {
+	return &ShortestPathTree{
+		Source: source,
+		dist:   map[int]int{source: 0},
+		parent: make(map[int]int),
+	}
+}
+
+// HasPathTo - достижима ли вершина v из источника
+func (t *ShortestPathTree) HasPathTo(v int) bool {
+	_, ok := t.dist[v]
+	return ok
+}
+
+// DistTo - длина кратчайшего пути до v или Infinity
+func (t *ShortestPathTree) DistTo(v int) int {
+	if d, ok := t.dist[v]; ok {
+		return d
+	}
+	return Infinity
+}
+
+// PathTo - вершины пути от источника до v или nil, если пути нет
+func (t *ShortestPathTree) PathTo(v int) []int {
+	if !t.HasPathTo(v) {
+		return nil
+	}
+	path := []int{v}
+	for v != t.Source {
+		// защита от цикла в родителях (после отрицательного цикла)
+		if len(path) > len(t.dist) {
+			return nil
+		}
+		v = t.parent[v]
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// PathEdges - рёбра пути от источника до v с их весами
+func (t *ShortestPathTree) PathEdges(v int) []Edge {
+	path := t.PathTo(v)
+	var edges []Edge
+	for i := 1; i < len(path); i++ {
+		u, w := path[i-1], path[i]
+		edges = append(edges, Edge{From: u, To: w, Weight: t.dist[w] - t.dist[u]})
+	}
+	return edges
+}
+
+// Reached - достигнутые вершины по возрастанию
+func (t *ShortestPathTree) Reached() []int {
+	vertices := make([]int, 0, len(t.dist))
+	for v := range t.dist {
+		vertices = append(vertices, v)
 	}
-	dist[start] = 0
+	sort.Ints(vertices)
+	return vertices
+}
+
+// Dijkstra
+func Dijkstra(g ReadOnlyGraph, start int) *ShortestPathTree {
+	tree := newShortestPathTree(start)
 
 	pq := &PriorityQueue{}
 	heap.Push(pq, &Item{vertex: start, dist: 0})
 
 	for pq.Len() > 0 {
 		u := heap.Pop(pq).(*Item)
-		for _, neighbor := range g.adj[u.vertex] {
-			if dist[u.vertex]+neighbor.weight < dist[neighbor.to] {
-				dist[neighbor.to] = dist[u.vertex] + neighbor.weight
-				parent[neighbor.to] = u.vertex
-				heap.Push(pq, &Item{vertex: neighbor.to, dist: dist[neighbor.to]})
+		if u.dist > tree.dist[u.vertex] {
+			continue
+		}
+		for _, neighbor := range g.Neighbors(u.vertex) {
+			d := u.dist + neighbor.Weight
+			if old, ok := tree.dist[neighbor.To]; !ok || d < old {
+				tree.dist[neighbor.To] = d
+				tree.parent[neighbor.To] = u.vertex
+				heap.Push(pq, &Item{vertex: neighbor.To, dist: d})
 			}
 		}
 	}
-	return dist, parent
+	return tree
 }
 
-// BellmanFord
-func BellmanFord(g *Graph, start int) ([]int, bool) {
-	n := len(g.adj)
-	dist := make([]int, n)
+// BellmanFord - кратчайшие пути при возможных отрицательных весах.
+// Останавливается, если очередной проход ничего не изменил.
+// Если из start достижим отрицательный цикл, возвращает его вершины
+// в порядке обхода (из последней есть ребро в первую), иначе nil.
+// В неориентированном графе отрицательное ребро u-v само образует
+// такой цикл из двух вершин.
+func BellmanFord(g ReadOnlyGraph, start int) (*ShortestPathTree, []int) {
+	n := g.NumVertices()
+	tree := newShortestPathTree(start)
+
+	// список дуг строим один раз; неориентированное ребро
+	// даёт две дуги, так как Neighbors возвращает обе стороны
+	var arcs []Edge
+	for _, u := range g.Vertices() {
+		for _, neighbor := range g.Neighbors(u) {
+			arcs = append(arcs, Edge{From: u, To: neighbor.To, Weight: neighbor.Weight})
+		}
+	}
+
+	relax := func(u, v, w int) bool {
+		du, ok := tree.dist[u]
+		if !ok {
+			return false
+		}
+		if dv, ok := tree.dist[v]; !ok || du+w < dv {
+			tree.dist[v] = du + w
+			tree.parent[v] = u
+			return true
+		}
+		return false
+	}
+
 	for i := 0; i < n; i++ {
-		dist[i] = math.MaxInt32
+		changed, last := false, 0
+		for _, arc := range arcs {
+			if relax(arc.From, arc.To, arc.Weight) {
+				changed, last = true, arc.To
+			}
+		}
+		if !changed {
+			return tree, nil
+		}
+		// изменение на n-м проходе означает отрицательный цикл
+		if i == n-1 {
+			return tree, tree.cycleFrom(last, n)
+		}
 	}
-	dist[start] = 0
+	return tree, nil
+}
 
-	for i := 0; i < n-1; i++ {
-		for _, edge := range g.GetAllEdges() {
-			u, v, w := edge.u, edge.v, edge.w
-			if dist[u]+w < dist[v] {
-				dist[v] = dist[u] + w
+// SPFA - вариант Беллмана-Форда с очередью, быстрее на разреженных графах.
+// Результат и отрицательный цикл - как у BellmanFord.
+func SPFA(g ReadOnlyGraph, start int) (*ShortestPathTree, []int) {
+	n := g.NumVertices()
+	tree := newShortestPathTree(start)
+	inQueue := map[int]bool{start: true}
+	edgesInPath := map[int]int{start: 0}
+	queue := Queue{}
+	queue.Enqueue(start)
+
+	for !queue.IsEmpty() {
+		u, _ := queue.Dequeue()
+		inQueue[u] = false
+		for _, neighbor := range g.Neighbors(u) {
+			v := neighbor.To
+			d := tree.dist[u] + neighbor.Weight
+			if dv, ok := tree.dist[v]; ok && d >= dv {
+				continue
+			}
+			tree.dist[v] = d
+			tree.parent[v] = u
+			edgesInPath[v] = edgesInPath[u] + 1
+			// путь из n и более рёбер проходит через отрицательный цикл
+			if edgesInPath[v] >= n {
+				return tree, tree.cycleFrom(v, n)
+			}
+			if !inQueue[v] {
+				inQueue[v] = true
+				queue.Enqueue(v)
 			}
 		}
 	}
+	return tree, nil
+}
 
-	for _, edge := range g.GetAllEdges() {
-		u, v, w := edge.u, edge.v, edge.w
-		if dist[u]+w < dist[v] {
-			return dist, true
+// cycleFrom - находит цикл в родителях, поднимаясь от x на n шагов
+func (t *ShortestPathTree) cycleFrom(x, n int) []int {
+	for i := 0; i < n; i++ {
+		p, ok := t.parent[x]
+		if !ok {
+			return nil
 		}
+		x = p
 	}
 
-	return dist, false
-}
\ No newline at end of file
+	cycle := []int{x}
+	for v := t.parent[x]; v != x; v = t.parent[v] {
+		cycle = append(cycle, v)
+	}
+	// по родителям идём против рёбер, поэтому разворачиваем
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
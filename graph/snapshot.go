@@ -0,0 +1,276 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Формат снимка (все числа - varint, если не указано иное):
+//
+//	magic "SGRF", версия (1 байт), флаги (1 байт, бит 0 - ориентированный граф)
+//	количество вершин n
+//	ID вершин: первый ID (знаковый), далее приращения
+//	для каждой вершины: степень d и d приращений номеров соседей в ids
+//	веса всех рёбер в том же порядке (знаковые)
+//	CRC32 (IEEE) всего предыдущего содержимого, 4 байта big-endian
+const (
+	snapshotMagic   = "SGRF"
+	snapshotVersion = 1
+
+	snapshotFlagDirected = 1 << 0
+
+	maxInt = int(^uint(0) >> 1)
+)
+
+var (
+	ErrSnapshotFormat    = errors.New("снимок: неверный формат")
+	ErrSnapshotVersion   = errors.New("снимок: неподдерживаемая версия")
+	ErrSnapshotTruncated = errors.New("снимок: файл обрезан")
+	ErrSnapshotChecksum  = errors.New("снимок: контрольная сумма не совпадает")
+)
+
+// WriteSnapshot - записывает граф в бинарном формате
+func WriteSnapshot(w io.Writer, g *Graph) error {
+	c := NewCSR(g)
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	putUvarint := func(x uint64) {
+		bw.Write(buf[:binary.PutUvarint(buf, x)])
+	}
+	putVarint := func(x int64) {
+		bw.Write(buf[:binary.PutVarint(buf, x)])
+	}
+
+	var flags byte
+	if c.directed {
+		flags |= snapshotFlagDirected
+	}
+	bw.WriteString(snapshotMagic)
+	bw.WriteByte(snapshotVersion)
+	bw.WriteByte(flags)
+
+	putUvarint(uint64(len(c.ids)))
+	for i, u := range c.ids {
+		if i == 0 {
+			putVarint(int64(u))
+		} else {
+			putUvarint(uint64(u - c.ids[i-1]))
+		}
+	}
+
+	for i := range c.ids {
+		row := c.edges[c.offsets[i]:c.offsets[i+1]]
+		putUvarint(uint64(len(row)))
+		prev := 0
+		for _, neighbor := range row {
+			j, _ := c.row(neighbor.To)
+			putUvarint(uint64(j - prev))
+			prev = j
+		}
+	}
+	for _, neighbor := range c.edges {
+		putVarint(int64(neighbor.Weight))
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	_, err := w.Write(sum[:])
+	return err
+}
+
+// snapshotReader - читает байты и одновременно считает контрольную сумму
+type snapshotReader struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+}
+
+func (sr *snapshotReader) ReadByte() (byte, error) {
+	b, err := sr.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	sr.crc.Write([]byte{b})
+	return b, nil
+}
+
+func (sr *snapshotReader) uvarint() (uint64, error) {
+	x, err := binary.ReadUvarint(sr)
+	return x, snapshotErr(err)
+}
+
+func (sr *snapshotReader) varint() (int64, error) {
+	x, err := binary.ReadVarint(sr)
+	return x, snapshotErr(err)
+}
+
+func snapshotErr(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrSnapshotTruncated
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSnapshotFormat, err)
+	}
+	return nil
+}
+
+// ReadSnapshot - читает граф, записанный WriteSnapshot
+func ReadSnapshot(r io.Reader) (*Graph, error) {
+	sr := &snapshotReader{r: bufio.NewReader(r), crc: crc32.NewIEEE()}
+
+	header := make([]byte, len(snapshotMagic)+2)
+	for i := range header {
+		b, err := sr.ReadByte()
+		if err != nil {
+			return nil, snapshotErr(err)
+		}
+		header[i] = b
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("%w: нет сигнатуры %q", ErrSnapshotFormat, snapshotMagic)
+	}
+	if version := header[len(snapshotMagic)]; version != snapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, version)
+	}
+	flags := header[len(snapshotMagic)+1]
+
+	n, err := sr.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	// число вершин проверяем до выделения памяти: испорченный varint
+	// не должен превращаться в отрицательную или огромную длину
+	if n > uint64(maxInt) {
+		return nil, fmt.Errorf("%w: слишком много вершин (%d)", ErrSnapshotFormat, n)
+	}
+	ids := make([]int, 0, minInt(int(n), 1<<20))
+	for i := uint64(0); i < n; i++ {
+		if i == 0 {
+			first, err := sr.varint()
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, int(first))
+			continue
+		}
+		delta, err := sr.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if delta == 0 {
+			return nil, fmt.Errorf("%w: ID вершин не возрастают", ErrSnapshotFormat)
+		}
+		if last := ids[len(ids)-1]; delta > uint64(maxInt-last) {
+			return nil, fmt.Errorf("%w: ID вершины вне диапазона", ErrSnapshotFormat)
+		}
+		ids = append(ids, ids[len(ids)-1]+int(delta))
+	}
+
+	offsets := make([]int, 1, len(ids)+1)
+	var targets []int
+	for range ids {
+		degree, err := sr.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		// соседи различны, поэтому степень не больше числа вершин
+		if degree > uint64(len(ids)) {
+			return nil, fmt.Errorf("%w: степень %d больше числа вершин", ErrSnapshotFormat, degree)
+		}
+		prev := uint64(0)
+		for k := uint64(0); k < degree; k++ {
+			delta, err := sr.uvarint()
+			if err != nil {
+				return nil, err
+			}
+			// сравнение без сложения, чтобы prev+delta не переполнилось
+			if delta >= uint64(len(ids))-prev {
+				return nil, fmt.Errorf("%w: сосед вне диапазона вершин", ErrSnapshotFormat)
+			}
+			j := prev + delta
+			targets = append(targets, int(j))
+			prev = j
+		}
+		offsets = append(offsets, len(targets))
+	}
+	weights := make([]int, len(targets))
+	for k := range weights {
+		w, err := sr.varint()
+		if err != nil {
+			return nil, err
+		}
+		weights[k] = int(w)
+	}
+
+	expected := sr.crc.Sum32()
+	var sum [4]byte
+	if _, err := io.ReadFull(sr.r, sum[:]); err != nil {
+		return nil, snapshotErr(err)
+	}
+	if binary.BigEndian.Uint32(sum[:]) != expected {
+		return nil, ErrSnapshotChecksum
+	}
+
+	g := NewGraph()
+	if flags&snapshotFlagDirected != 0 {
+		g = NewDirectedGraph()
+	}
+	for i, u := range ids {
+		g.AddVertex(u)
+		for k := offsets[i]; k < offsets[i+1]; k++ {
+			v := ids[targets[k]]
+			if g.directed || u <= v {
+				g.AddEdge(u, v, weights[k])
+			}
+		}
+	}
+	if err := g.CheckInvariants(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotFormat, err)
+	}
+	return g, nil
+}
+
+// SaveSnapshot - сохраняет снимок графа в файл
+func SaveSnapshot(path string, g *Graph) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = WriteSnapshot(f, g)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// LoadSnapshot - загружает снимок графа из файла
+func LoadSnapshot(path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	g, err := ReadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return g, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
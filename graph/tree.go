@@ -0,0 +1,287 @@
+package graph
+
+import "sort"
+
+// Tree - корневой лес, построенный из рёбер (например, результата MST).
+// Корень каждой компоненты - вершина с наименьшим ID. Запросы к путям
+// отвечаются двоичным подъёмом за O(log n).
+type Tree struct {
+	ids    []int       // ID вершин по возрастанию
+	index  map[int]int // ID -> номер
+	parent []int       // номер родителя или -1 у корня
+	weight []int       // вес ребра до родителя
+	depth  []int
+	root   []int // номер корня компоненты
+	dist   []int // сумма весов от корня
+	order  []int // порядок обхода в ширину (родители раньше детей)
+
+	up    [][]int // up[k][v] - предок на 2^k уровней выше
+	maxUp [][]int // вершина с самым тяжёлым ребром до родителя на этом отрезке
+}
+
+// NewTree - строит лес из рёбер; рёбра должны образовывать лес
+func NewTree(edges []Edge) *Tree {
+	adj := make(map[int][]Neighbor)
+	for _, edge := range edges {
+		adj[edge.From] = append(adj[edge.From], Neighbor{To: edge.To, Weight: edge.Weight})
+		adj[edge.To] = append(adj[edge.To], Neighbor{To: edge.From, Weight: edge.Weight})
+	}
+
+	t := &Tree{index: make(map[int]int)}
+	for id := range adj {
+		t.ids = append(t.ids, id)
+	}
+	sort.Ints(t.ids)
+	n := len(t.ids)
+	for i, id := range t.ids {
+		t.index[id] = i
+	}
+	t.parent = make([]int, n)
+	t.weight = make([]int, n)
+	t.depth = make([]int, n)
+	t.root = make([]int, n)
+	t.dist = make([]int, n)
+	visited := make([]bool, n)
+
+	for r := 0; r < n; r++ {
+		if visited[r] {
+			continue
+		}
+		visited[r] = true
+		t.parent[r] = -1
+		t.root[r] = r
+		queue := []int{r}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			t.order = append(t.order, v)
+			for _, neighbor := range adj[t.ids[v]] {
+				c := t.index[neighbor.To]
+				if visited[c] {
+					continue
+				}
+				visited[c] = true
+				t.parent[c] = v
+				t.weight[c] = neighbor.Weight
+				t.depth[c] = t.depth[v] + 1
+				t.root[c] = r
+				t.dist[c] = t.dist[v] + neighbor.Weight
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	t.buildLifting()
+	return t
+}
+
+func (t *Tree) buildLifting() {
+	n := len(t.ids)
+	levels := 1
+	for 1<<uint(levels) < n {
+		levels++
+	}
+	t.up = make([][]int, levels)
+	t.maxUp = make([][]int, levels)
+	t.up[0] = make([]int, n)
+	t.maxUp[0] = make([]int, n)
+	for v := 0; v < n; v++ {
+		t.up[0][v] = t.parent[v]
+		t.maxUp[0][v] = v
+	}
+	for k := 1; k < levels; k++ {
+		t.up[k] = make([]int, n)
+		t.maxUp[k] = make([]int, n)
+		for v := 0; v < n; v++ {
+			mid := t.up[k-1][v]
+			t.maxUp[k][v] = t.maxUp[k-1][v]
+			if mid < 0 {
+				t.up[k][v] = -1
+				continue
+			}
+			t.up[k][v] = t.up[k-1][mid]
+			t.maxUp[k][v] = t.heavier(t.maxUp[k-1][v], t.maxUp[k-1][mid])
+		}
+	}
+}
+
+// heavier - из двух вершин та, у которой ребро до родителя тяжелее
+func (t *Tree) heavier(a, b int) int {
+	if t.parent[b] >= 0 && (t.parent[a] < 0 || t.weight[b] > t.weight[a]) {
+		return b
+	}
+	return a
+}
+
+// Vertices - вершины леса по возрастанию
+func (t *Tree) Vertices() []int {
+	ids := make([]int, len(t.ids))
+	copy(ids, t.ids)
+	return ids
+}
+
+// Parent - родитель вершины v; false для корня и отсутствующей вершины
+func (t *Tree) Parent(v int) (int, bool) {
+	i, ok := t.index[v]
+	if !ok || t.parent[i] < 0 {
+		return 0, false
+	}
+	return t.ids[t.parent[i]], true
+}
+
+// Depth - глубина вершины (число рёбер до корня) или -1
+func (t *Tree) Depth(v int) int {
+	i, ok := t.index[v]
+	if !ok {
+		return -1
+	}
+	return t.depth[i]
+}
+
+// lca - наименьший общий предок в номерах и самое тяжёлое ребро на пути
+func (t *Tree) lca(u, v int) (int, int) {
+	heaviest := u
+	if t.depth[u] < t.depth[v] {
+		u, v = v, u
+		heaviest = u
+	}
+	for k := len(t.up) - 1; k >= 0; k-- {
+		if t.depth[u]-(1<<uint(k)) >= t.depth[v] {
+			heaviest = t.heavier(heaviest, t.maxUp[k][u])
+			u = t.up[k][u]
+		}
+	}
+	if u == v {
+		return u, heaviest
+	}
+	for k := len(t.up) - 1; k >= 0; k-- {
+		if t.up[k][u] != t.up[k][v] {
+			heaviest = t.heavier(heaviest, t.heavier(t.maxUp[k][u], t.maxUp[k][v]))
+			u, v = t.up[k][u], t.up[k][v]
+		}
+	}
+	heaviest = t.heavier(heaviest, t.heavier(u, v))
+	return t.parent[u], heaviest
+}
+
+// pair - номера вершин u и v, если они в одной компоненте
+func (t *Tree) pair(u, v int) (int, int, bool) {
+	i, ok1 := t.index[u]
+	j, ok2 := t.index[v]
+	if !ok1 || !ok2 || t.root[i] != t.root[j] {
+		return 0, 0, false
+	}
+	return i, j, true
+}
+
+// LCA - наименьший общий предок u и v
+func (t *Tree) LCA(u, v int) (int, bool) {
+	i, j, ok := t.pair(u, v)
+	if !ok {
+		return 0, false
+	}
+	a, _ := t.lca(i, j)
+	return t.ids[a], true
+}
+
+// PathMax - самое тяжёлое ребро на пути между u и v
+func (t *Tree) PathMax(u, v int) (Edge, bool) {
+	i, j, ok := t.pair(u, v)
+	if !ok || i == j {
+		return Edge{}, false
+	}
+	_, h := t.lca(i, j)
+	return Edge{From: t.ids[t.parent[h]], To: t.ids[h], Weight: t.weight[h]}, true
+}
+
+// PathSum - сумма весов на пути между u и v
+func (t *Tree) PathSum(u, v int) (int, bool) {
+	i, j, ok := t.pair(u, v)
+	if !ok {
+		return 0, false
+	}
+	a, _ := t.lca(i, j)
+	return t.dist[i] + t.dist[j] - 2*t.dist[a], true
+}
+
+// Diameter - самый длинный по сумме весов путь в лесу и его длина
+func (t *Tree) Diameter() (int, []int) {
+	n := len(t.ids)
+	// две самые длинные ветви вниз из каждой вершины
+	down1, down2 := make([]int, n), make([]int, n)
+	child1, child2 := make([]int, n), make([]int, n)
+	for v := 0; v < n; v++ {
+		child1[v], child2[v] = -1, -1
+	}
+
+	best, top := 0, -1
+	for k := n - 1; k >= 0; k-- {
+		v := t.order[k]
+		if top < 0 || down1[v]+down2[v] > best {
+			best, top = down1[v]+down2[v], v
+		}
+		p := t.parent[v]
+		if p < 0 {
+			continue
+		}
+		length := down1[v] + t.weight[v]
+		if length > down1[p] {
+			down2[p], child2[p] = down1[p], child1[p]
+			down1[p], child1[p] = length, v
+		} else if length > down2[p] {
+			down2[p], child2[p] = length, v
+		}
+	}
+	if top < 0 {
+		return 0, nil
+	}
+
+	var path []int
+	for v := child1[top]; v >= 0; v = child1[v] {
+		path = append([]int{t.ids[v]}, path...)
+	}
+	path = append(path, t.ids[top])
+	for v := child2[top]; v >= 0; v = child1[v] {
+		path = append(path, t.ids[v])
+	}
+	return best, path
+}
+
+// Centroids - центроид каждой компоненты: вершина, после удаления
+// которой все части содержат не больше половины вершин компоненты
+func (t *Tree) Centroids() []int {
+	n := len(t.ids)
+	size := make([]int, n)
+	for k := n - 1; k >= 0; k-- {
+		v := t.order[k]
+		size[v]++
+		if p := t.parent[v]; p >= 0 {
+			size[p] += size[v]
+		}
+	}
+
+	// максимальная часть после удаления v
+	heaviestPart := make([]int, n)
+	for v := 0; v < n; v++ {
+		heaviestPart[v] = size[t.root[v]] - size[v]
+	}
+	for v := 0; v < n; v++ {
+		if p := t.parent[v]; p >= 0 && size[v] > heaviestPart[p] {
+			heaviestPart[p] = size[v]
+		}
+	}
+
+	best := make(map[int]int)
+	for _, v := range t.order {
+		r := t.root[v]
+		if c, ok := best[r]; !ok || heaviestPart[v] < heaviestPart[c] || (heaviestPart[v] == heaviestPart[c] && v < c) {
+			best[r] = v
+		}
+	}
+	var centroids []int
+	for _, c := range best {
+		centroids = append(centroids, t.ids[c])
+	}
+	sort.Ints(centroids)
+	return centroids
+}
@@ -0,0 +1,88 @@
+package graph
+
+import "container/heap"
+
+// WidestPath - путь от s до t с максимальным весом самого слабого ребра
+// (модифицированный Dijkstra). Возвращает путь, вес узкого места
+// и false, если t недостижима.
+func WidestPath(g ReadOnlyGraph, s, t int) ([]int, int, bool) {
+	if !g.HasVertex(s) || !g.HasVertex(t) {
+		return nil, 0, false
+	}
+	// в дереве вместо расстояния хранится узкое место пути
+	tree := newShortestPathTree(s)
+	tree.dist[s] = Infinity
+	done := make(map[int]bool)
+
+	// очередь упорядочена по возрастанию, поэтому кладём -ширину
+	pq := &PriorityQueue{}
+	heap.Push(pq, &Item{vertex: s, dist: -Infinity})
+
+	for pq.Len() > 0 {
+		u := heap.Pop(pq).(*Item).vertex
+		if done[u] {
+			continue
+		}
+		done[u] = true
+		if u == t {
+			return tree.PathTo(t), tree.dist[t], true
+		}
+		for _, neighbor := range g.Neighbors(u) {
+			width := minInt(tree.dist[u], neighbor.Weight)
+			if old, ok := tree.dist[neighbor.To]; !done[neighbor.To] && (!ok || width > old) {
+				tree.dist[neighbor.To] = width
+				tree.parent[neighbor.To] = u
+				heap.Push(pq, &Item{vertex: neighbor.To, dist: -width})
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// WidestPathMST - то же через максимальное остовное дерево (MST с обратными
+// весами): путь в нём между s и t всегда самый широкий.
+// Только для неориентированного графа.
+func WidestPathMST(g *Graph, s, t int) ([]int, int, bool) {
+	if g.directed || !g.HasVertex(s) || !g.HasVertex(t) {
+		return nil, 0, false
+	}
+
+	ids, edges := indexedEdges(g)
+	for i := range edges {
+		edges[i].Weight = -edges[i].Weight
+	}
+	mst, _ := MST(len(ids), edges)
+
+	tree := NewGraph()
+	for _, edge := range mst {
+		tree.AddEdge(ids[edge.From], ids[edge.To], -edge.Weight)
+	}
+	tree.AddVertex(s)
+
+	path, _, ok := ShortestHopPath(tree, s, t, 0)
+	if !ok {
+		return nil, 0, false
+	}
+	width := Infinity
+	for i := 1; i < len(path); i++ {
+		w, _ := tree.Weight(path[i-1], path[i])
+		width = minInt(width, w)
+	}
+	return path, width, true
+}
+
+// indexedEdges - рёбра графа с вершинами, перенумерованными в 0..n-1;
+// ids[i] - исходный ID вершины с номером i
+func indexedEdges(g *Graph) ([]int, []Edge) {
+	ids := g.Vertices()
+	index := make(map[int]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	edges := g.GetAllEdges()
+	for i := range edges {
+		edges[i].From = index[edges[i].From]
+		edges[i].To = index[edges[i].To]
+	}
+	return ids, edges
+}